@@ -18,39 +18,44 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xZbXPbNhL+Kzu4zjSZE/Vm1Wn05Sa10oa52PHFTju92JfC5FJCAgIIAFpSPfrvNwBI",
-	"SpQo1elNfPnQbyIJ7D7Yl2d3oTuSyFxJgcIaMr4jimqao0Xtn2JhLBUJxuk5tTP3JkWTaKYsk4KMyVvB",
-	"PhUILEVhWcZQg8zAzhBYuZF0CC5orjiSMRkMj3D03fGTCL9/ehMNhulRREffHUej4fHxYDR4Mur3+6RD",
-	"mJOsnL4OETR3O1mNg3SIxk8F05iSsdUFdohJZpjTAN5a1G77f97R6Pd+9PT6Ufkjur7rd44Hq+r94398",
-	"QzrELpUTb6xmYkpWq1UlzZ/+udZS7x76zY8n8OT7/hNwluOMCgvoVoJGo6Qw7tBKS4XaMjRhv6WM70p6",
-	"UeRURBppSm84Ai4Up4K6j2AUJixjCVgJdsYMyCQptMZtm17OEL51VvoWMoY8BWagMhDcFBbm1ICQFpSW",
-	"tyzFdPfUndq8LQ5+E4PGDL1iyKQOYGp04eB7sPX8V9O7p98zqXNqyZgUmkW10ja8xlJbmBZ7Xl6eQ/gI",
-	"iUwbaEb9fi2JCYtT1E6UZZa3nPtiJrWFWdM/pshzqpdVjCstbzjmjSPH4pZylkIsVGHboIcXh81cptOS",
-	"ialXFIzsd27qmlmrzLjXS5O8W77tJjKvrM4ClIiVUO5r3tVmhr0jpdpgp+t6tbz5gIl1J3qBlLdxQ3hf",
-	"ucMwMeVopXBZIgud7GaJaqWYEyqkYAnl4L5Xtt8QsmGQgMThp+lrwZcVQ9w/gDYxt8hetpqraZIOWUQU",
-	"VVRDXBOTcQYtUV53iOKFprwW7hTWZqrJjYlpwanePF6FAPUtS7BMbN11ccBkr1zmgF2EFZdLhfHeFP+x",
-	"4BxKWT7IavKurQwalUaDwnpy2vFcopFafG9Z3iL/kuVoLM0VzGcoGtXBk1PGtLEQRKSbcZpSi5GXeQ+P",
-	"svQ+1SkQGEJpGHCWgfh/qFU5XbxCMXWBe3zUITkT1eOg8/nl6A9P2Z4ibyo3+QxZH7dxoNLDkZMZVQ64",
-	"LzX/MbAyCN+Hgr2N8IzmuEGbfmkD3cfiBm+ZttFgeNTK+AoTJ5amKXMyKT/fiMAAaovBSxdXpSqU1Uxy",
-	"LueOWKWoEZlCKaktpo00uBJlKwCPfj69UJh04EQKS5lAHR4n1NIbajA8SQ0nvDA2fH3cvRKkhS0Llf75",
-	"VOHUWAgS/mymbLF702+lna8/l9JaY8v07qqf71m6ahBe6wbSYLt9Sw6TX/uuPVz4ihm764RzOmXCWRg4",
-	"M9bFSH2iHeJbf3EPFnP/4xuNGRmTv/XWrXWvjKVeGyWvSwjVmi7ds8CFfa/oFN9b+RFFS6i4157PNFrN",
-	"8LZqFtxOcDsdco2m4LZZyXD5Uv37JD6OPzxfng7f9s8ufz169cvb0etfYnt6+fLj6XIwO5u8Hb66/Nfy",
-	"7MOvi7PJ86OzybP56cnLp/cof76JZiKTvjC4hEmckVfb+Tk5OYWLc6i565QKOsUchYVn5zFEcOIqgstZ",
-	"KlLI119lVtN3o1qZ7pW4dM2p287cYd1ys5/vIeNyDtRAihkTmALzKXclHDQUM7fGa3Q+l4bykNKcJeia",
-	"/PFdNZ08UzSZIQy7jioLzTd6s/l83qX+c1fqaa/ca3qv4pPnZxfPo2G3353ZnG+0ojVznZex/eji/PE+",
-	"O5EOuUVtgklvB5SrGR04YVKhoIqRMTnq9rsjEmqHD8+qQxjfkSnavU1QMsPko4+ww64iXpn2vopTMiY/",
-	"oX2x7sTCSOQVD/v9KihQeMVUKV5Sc++DkT7O1+PcoTx6UXU5O4H1+p8+Kstmfes8LoDptNGHucUN3jBR",
-	"I61bbfQGbaGFAVqTRGsHZWDOXNOqQs2CjHGLPnG2jea4KN7kmY05/N1Oy+bFNFRWc/OnAvVyPTiXn9Y2",
-	"3UnfbdmndMHyIgdR5Ddhni9JBBRqTyx7VOV0ERjLsN+bOlPMaMEtGQ/cDJYHBdUTE+XT7nS2C27Neiqw",
-	"dOhG2+BskOeh819/wRjdV3JagvaiSBI0Jis41HHh0nh0EE45gf7982CFa40WED/Q1N8doPGtSu22h9L/",
-	"VuBCYeJKL5ZrNvPY2Q7ovmHFbKR2XfivXWsq26q8ry4IFATO102WzIA2xHevxOsyefmy7CWXQCHhDIWN",
-	"qDFs6kpHPIErccuorza/sfQ38OEIdRp3Ic4a9zCdUGucMtQwZ5zDFIVzPQIVEE9CtWmyREC9MbAcpIkK",
-	"uUPncqbVbnvSh6XkwM3aZ16rXYemE439QabLL5lfIayaN4SrnRQffHkITU/ULUc15j50alf3UuEyyGt/",
-	"+nDaT6TIOEssRHXYxROg3M0qS9d2FcZ3waPh8OFA/ewMEjpMXCSoKsr92mivYipxgKbaue9AY7OezeJ0",
-	"FdiRo8W2RieXtw3tO6Q08Vv3k1KbUdZLelv/MbRU5NEurDqhAu4UTF1A+fL/ll3xxN8lcFZl+OghMZQW",
-	"cUUmk4VIv8ZgDrGyFU6tZbu17f4JbSMRbpbArIEiXPTFk7ZZ5AsGZv+h68hX0Sb+Fe33jfbtcN0aE/ew",
-	"thPhu8IQpeFCoUcV660H/Ot6684/V+2Deh0uZvdfVbI7aDXuANr2Vv8yXK/+GwAA///vD62UQh4AAA==",
+	"H4sIAAAAAAAC/+xabXPbNhL+KxhcZ5rMiXqz6jS6DzeplTbMxY4vdtrpxT4XIpcSEhBAAFAvzei/3wAg",
+	"KVKCXDvX+PLhvpkEsXiw2H2excqfcCJyKThwo/H4E5ZEkRwMKPcUc20ITyBOz4mZ2zcp6ERRaajgeIzf",
+	"cvqxAERT4IZmFBQSGTJzQLSciDsYViSXDPAYD4ZHMPru+EkE3z+dRoNhehSR0XfH0Wh4fDwYDZ6M+v0+",
+	"7mBqLUu7XgdzktuZtMaBO1jBx4IqSPHYqAI6WCdzyIkHbwwoO/3f70j0ez96ev2o/CO6/tTvHA821fvH",
+	"f/8Gd7BZS2teG0X5DG82m8qa2/1zpYTa3/SbH0/Qk+/7T5D1HKOEGwT2S6RAS8G13bRUQoIyFLSfbwhl",
+	"+5ZeFDnhkQKSkikDBCvJCCd2EGkJCc1ogoxAZk41EklSKAW7Pr2cA/rWeulblFFgKaIaVQ5C08KgJdGI",
+	"C4OkEguaQrq/607t3sABv4mRggzcwigTyoOp0fmNH8DWc6O6d8dzz4TKicFjXCga1YuG8GpDTKED/ry8",
+	"PEd+ECUibaEZ9fu1JcoNzEBZU4YaFtj3xVwog+bt89FFnhO1rmJcKjFlkLe2HPMFYTRFMZeFCUH3L253",
+	"c5lOa8pnbiHvZDezudbcGKnHvV6a5N3ybTcReeV16qFEtIRyV/dumhn2DpfLej9d11+L6XtIjN3RCyAs",
+	"xA3+fXUcmvIZAyO4zRJRqGQ/S2SQYk4IF5wmhCE7Xvm+YaThEI/E4ifpa87WFUPcPYCamAO210F3tV3S",
+	"wauIgIxqiFti0tahJcrrDpasUITVxu2CtZtqcqN8VjCimturEIBa0ATKxFZdGwdU9MrPLLAL/8XlWkJ8",
+	"MMV/LBhDpS0XZDV5115GCqQCDdw4cto7uUQBMXBjaB6wf0lz0IbkEi3nwFvq4Mgpo0ob5E2kzThNiYHI",
+	"2bzDidL0LurkCQxQ6RhkPYPi/0KrcrJ6BXxmA/f4qINzyqvHQef+cvTHuyyh3nhd3N3wGclhV4E7qPBu",
+	"ILngs9aQtt9SoyttUC0PLGEaZUpwAzz903e6t7Nw8r+pAtAONw6yBbSM3cjajKq93VV0/tDllW/u4PKg",
+	"Gz8UU1hQZaLB8CioZRISa5akKbU2CTtv5JYHtaNNZfBWIuwLhkwwJpZWMgSvEelCSqEMpK0Ev+JlkYMe",
+	"/Xx6ISHpoBPBDaEclH+cEEOmRIN/EgqdsEIbP/q4e8VxQAcKmX4+CTCiDfIWPpcDdnSrfW6ln6/vS9bB",
+	"2NK9T3Ui0nTTovLgBNzi8UOf3E7r4VkHWP4V1Wb/EM7JjHLrYcSoNi75qx3tUfp2xD4YyN0f3yjI8Bj/",
+	"pbe9NPTKWOoFYGwLHkyUImv7zGFlbiSZwY0RH4AHQsW+dkytwCgKi6oMsjORnWmRK9AFM22NhvVL+a+T",
+	"+Dh+/3x9OnzbP7v89ejVL29Hr3+Jzenlyw+n68H8bPJ2+Oryn+uz97+uzibPj84mz5anJy+fBms1YQi7",
+	"0fT3UN4X+dRfd7ZsmhOTzCu0GWUGlEYkUUJrRBhz2HUHiZwaewg2E664/kDlzXYlW79rMFe8ubPRsJES",
+	"lJvjEd6vZfcKEXedoTwTTqJtgic2KDa7fDI5OUUX56jm2lPCyQxy4AY9O49RhE4UeI4hPEX5dlRktZC2",
+	"6gbdveKX9ppgp1O7Bfu5Pqy8KGNiiYhGKWSUQ4qoo4grbqEBn9tv3Io2RoUmzFMQowlw7Q6nvCc+kySZ",
+	"Axp2LbUXijWq5OVy2SVuuCvUrFfO1b1X8cnzs4vn0bDb785NzhqXgpppz8tcfHRx/viQn3AHL0Bp79LF",
+	"gDA5JwNrTEjgRFI8xkfdfteenBUzl05VrTb+hGdgDpajyRySDy4jbj8q7BZT7qziFI/xT2BebGtifzl1",
+	"Cw/7/SoogLuFiZSslJLeey1cXm4v1rfl/Yuq3twLrNf/cFFZXpt29mMjmMxaFbH9uMVzOmrRUNBHb8AU",
+	"imtEalIL1rIaLam9PkivsWV62kTfdZrlzrjJi42OyLu94tmZaS1ZdTA+FqDW2xZGObT16Z587dq2WoeU",
+	"215jG5Q3BF5DeVcB/TekQAIxtmHgiAhpWIAVJUskkokUKuEMwauvPFuANesfuMRuOV2btcsXy1H4rhsp",
+	"K35EjC0wSGagbC5YuUePbJfl6Ojo6eMD/iyn37iJLdzB4uEzvV2BnEImFHwOPj/zTwB4SlY0L3LEa+Up",
+	"NRBJUE5bDiDJycoLrlOyJpAUMlIwg8cD2xzJ/QLVE+XlU0hqDou29EWGvyaG4DS0/17pMBGuj5WIghsn",
+	"JbXc1sfVscGvyQIQQW5VJDhiRM0AGdvB0Qcg7Whw2EcZYXpbdU6FYEA43myuvyC3HirtAmR7USQJaJ0V",
+	"DNV8ZnNzdCucsof11/vB8o3RAIgfSOq6j6DdlaD23UOt/5bDSkJiMxbKb5r6Y33nKrGwRDQkqXqHr+0V",
+	"UISqaVcV2UjjsKxt2LQkLfPdK/66FB22Lu9sa0RQwihwExGt6cyWPPEEXfEFJS60f6Ppb2UE1/LTRXHW",
+	"6uR2fI1kFwOFlpQxNANujx4Q4Sie+CqprW4edaPlcau8VcgtOpvcQb8dSCqa4lt68/dszF/7yx1o84NI",
+	"118yv3xYtX9j2Oyl+ODLQ2ifRDVWN8oeOrWrzrZvJ7vVRw+3+mWjYfDttmGFUgH+5w1YUV3CevpwsE4E",
+	"zxhNDIpqdPEEEaaApGtbqhXaXYJHw+HDgfrZnpOzjGCVgKyU4Gtj44pA+S3sGabkW+4J29ZMnG48aTMw",
+	"ELo35GLRWn2PKydu6mGuDDll+0lv58fTQKEw2odV57nHnSJd6zpb/8+SPp64ViKjFfGMHhJD6RGb5pko",
+	"ePo1BrOPlZ1wClYTwVvsT2BaiTBdu8Z82bqPJ6Gr/RcMzP5Dy9tXUb3+P9rvGu274brTdTnA2taEK1Z9",
+	"lPr+XI9I2tv2y67rqXs/yYf7XnW46P1/Fwn0IlottdDc6ufT681/BgAXAl15GyMAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -42,6 +42,9 @@ type ServiceTypeInstance struct {
 	// Id Unique identifier for the Service Type Instance
 	Id *string `json:"id,omitempty"`
 
+	// InstanceName Name of the instance, unique among the instances of its provider
+	InstanceName *string `json:"instance_name,omitempty"`
+
 	// Path Resource path identifier
 	Path *string `json:"path,omitempty"`
 
@@ -62,6 +65,10 @@ type ServiceTypeInstanceList struct {
 
 	// NextPageToken Token for retrieving the next page of results
 	NextPageToken *string `json:"next_page_token,omitempty"`
+
+	// TotalSize Number of instances matching the filters across all pages, omitted when
+	// skip_total_size is set
+	TotalSize *int64 `json:"total_size,omitempty"`
 }
 
 // InstanceIdPath defines model for InstanceIdPath.
@@ -72,11 +79,23 @@ type ListInstancesParams struct {
 	// Type Filter service type
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 
+	// Status Only return instances in one of these statuses; repeat to match several
+	Status *[]string `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedAfter Only return instances created at or after this time (RFC 3339)
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only return instances created before this time (RFC 3339)
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
 	// MaxPageSize Maximum number of results per page
 	MaxPageSize *int `form:"max_page_size,omitempty" json:"max_page_size,omitempty"`
 
 	// PageToken Token for pagination
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
+
+	// SkipTotalSize Do not count the matching instances, to save a query on large tables
+	SkipTotalSize *bool `form:"skip_total_size,omitempty" json:"skip_total_size,omitempty"`
 }
 
 // CreateInstanceParams defines parameters for CreateInstance.
@@ -18,47 +18,123 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xabXPbNhL+KxhcZ5pe9W43afTlxrXTRm3s+By7nV7i80DkUkICAggAymZ9+u83APgq",
-	"QrbcSZ3M3H2TSWB3sdh99tmlb3EkUik4cKPx9BbraAkpcT9fKCWU/RGDjhSVhgqOp/jsx0P07PvRM2Q3",
-	"Mkq4QWBXIgVaCq4B97BUQoIyFLTfbwhlXUkvs5TwvgISkzkDBDeSEU7sS6QlRDShETICmSXVSERRphTw",
-	"yIqHG5JKBniKz5eAvuYkha9RQoHFiGqk4GNGFcRonhl0TTTiwiCpxIrGEOMeNrm0W7VRlC/wuocp14ZY",
-	"yR0LL85mSEECTjFKhPLGVNb5g2+xbeje6uF4sgf73z191ofvn8/740m81yf73z3t70+ePh3vj5/tj0Yj",
-	"3MOJUCkxeIozRfuV0pC92hCT6YA/z89PkX+JIhG3rNkfjSpJlBtYgLKiDDUscO43S6EMWrbvR2dpSlSO",
-	"RILMEqxH5wzS1pFnfEUYjdGMy8yETPcP7nYzjYEbmuSUL5wi72S3s6lraYzU0+EwjtJB8XQQibT0OvWm",
-	"9Glhyq7uXfdwGUB4+hYXar2fLqvVYv4eImNP9BIIM8vAZbjn5XVoyhcMjOA2S0Smom6WSBISc0i44DQi",
-	"DNn3pe8bQhoO8ZZY+0n8mrMcT43K4CEB1LQ5IDsPuqvtkh6+6ROQ/cpEfzQDimvr0MLKyx6WLFOEVcKt",
-	"wspNpen2QcaIah6vtADUikZQJLYa2DigYlgss4adFq+6B/0xY6yEBFV5EymQCjRw40Coc0ORAmLgytA0",
-	"EMPnNAVtSCrR9RJ4mSFegQWhhCptkIIF1QaUA6IqJGNioO/E7nB5MdWSkfzKot69mFosRnZxgV+1Xa0b",
-	"/iWbw69UGfTG+xWd1qs6NgCPpaDcbPFs+RpdnL2y7lDQ9sfB6cwCNYki0JrOWTixtRy3EptIOlyNCZNL",
-	"Mh6u0o2cDpnpY+Fqp3BvwFrXOdaf+S6XQ+MAunH6MatgjYKqLiLg6lrng8tGRuNdTEzBkJgYYg39SkGC",
-	"p/hvw5oEDAsGMCyNOi7Xr3s4HHPFAV2MFV6882QfsjmsqDL98WQvdG0251wGBu7sFdXGKqnXIJ1JKZSB",
-	"uFGgC/WbReNtkcO4h2Ng4H5k0qafBSRqIHUqt5QtTJQiuf07jNVnJY44qK7vu3X4Mr525gX3o7m7sKsV",
-	"KO3s6BRz9x4V78sbarrIX9lp6ck29Jcph3sljuMp/vfq7aj//PLbJ+7df+ZgyDf/cI/+/lWQsnhtV+H6",
-	"f25tEEltk73DCi1EkoDasCl9CC86c6CrCmZZFTeepTYiWpDsgyG20dDM/saKe6/Di/iTVYIRbVBpxJ+r",
-	"EBsExqVsA7E37qITPpcPLeh1QN+WP69ovG5V+GoNbpV02S0x4aJeLWyWdQsFXQ+fkgXl1n2IFVDRVN4u",
-	"6BxuzJUkC7gy4gMEUufcPnawosAoCquSk9qdyO60ChTojG1kDeQ/y38dzp7O3r/IjycXo5Pz3/de/Xax",
-	"//q3mTk+//nDcT5enhxdTF6d/zM/ef/7zcnRi72To4Pr48Ofn4diuz7E9LYGql3wu4tf6wCN7aD99BaT",
-	"OKbWEYSdNtzmg67tp4NqJSrLCyJzkZnNktr2v00swa9cr9Lx/U8gForIJY2QX+d6mhCd8LUA2heQ6T4Q",
-	"bfrjkDfLWL7XiSWkHxJJImryu2Dm0DWBpi5NhO3CLbZS6x7+Q/CAYw5WhDIyp4yaHNklSChkXR4BN6C2",
-	"FZ56RX++A49f93Dn8NsrXlQsQZR7zArxZyMMYVeRzEKJZghDh6cXKBIKNCL+jG1mONnSwzqxKaRC5dsk",
-	"+7dhsXh8/kOwV3VyeTA4vVSepXNXoJBd1Yq/8V22aiMUWWwVW7zeYu0kZG33+tZusJEI17gIbkhkwXK9",
-	"mblHh8cdsub4eR+1yibhMUoJJwtIXYwnnV168I6f28Lt2L011q7UQTpY9EGF7ISJa0Q0iiGhHGJEXWF8",
-	"x61twJeER16pjSahCRu8s7HFaARcOyd6WooPJImWgCYDS5syxRq9xPX19YC41wOhFsNirx6+mh2+OHnz",
-	"oj8ZjAZLk7LGTASH3IJ7uOJZNTPynJUTSfEU7w1Gg31PlpYu7MuWdHqLF2C2tiHREqIPrtZsuxPcoMaz",
-	"2EGkeVk3/X765lRORqPy3sF3aURKRiO3dfhee5roQe4+CHxZNtSd2Hn9iwu8Yi60cRIbo2TRavnt4mGr",
-	"kAX9cQYmU1wjUlXwmoBVFLESg66pWSIhC8BNKDPgcmLTWZYunDapAFEkBeMsedtpY52YhpZ5vtlUULvu",
-	"YwbKQncRgS1KFegk1r1NRcfkhqZZ2oCSgk0gafVblAirSsmNpy6a/tHWGUNCMmYKDEq9gvIvyou/uujU",
-	"Na6mP9KzKo/qIXMaLOqu81/+hYHa4oWBcH2TuXlDkrG6RNvU3b/ThmLM+e3DbPGz84ARP5DYDahBuxJb",
-	"3dVj6b/gcCMhsvwYijXNDHbtNWkMx3Qjjyv6cmkJqdBmW6sFChHE4bqTrZap+O4GEY7ghmpjGbXgMHjH",
-	"W/WGastjUimsT6bveB/NEj9hiAX4kb7b3is0vTlFwI3K7Ubf48fNTW5tgRWapDDkojJqdtTzc+Zyf9F/",
-	"bdsf08RNj01LQruiEco0ehIJnjAamW8KUfX6LQKtrntFdZDt0J23MWq5E9pel1BZXcrsyOV47e+WBVsS",
-	"3g2b6mDcHEMFE9+F/Q8izj95zvtQr/te26SsHwFrQilWlewijtATBf2mR7+xmT8ZjR/XmiIr0BObLh1z",
-	"HhUEy89E/tuM0/788bQfFqmE+sXIUjUTkzA37LUkNNPgjJtMHs+4X61jfObDTQSyLFJfWqFoAH3og0G3",
-	"YrS4Xz0qmsVrX0XcNDZQT1KxAkS6lSRRIi2+hblQzjuweORk7gqL3Sn9RtOOjEDV0NghopsC1wyoOhHe",
-	"RKIHA2ULtfYDQ67SJm9QjHTFbVj+2dJ5duQmyIxC7G3YfzwbKo9YZpCIjMdfYtb4kGyE89050ws3SD+B",
-	"CWXEPEfUaJT5QJ4dhdrFT5YNj5wDj1O5v4gO4f/ZtGs2+TyQ96SQpRjdT5bdJmQzn9w4C5rUgBYfbIhZ",
-	"ojTTBqXERMuWkMbmTvodSMnyT1qOik+Xf3kq/o/y9i+iqDY48mcHgM9K09vE3FY7woVZNqZ0XyJGlUCz",
-	"K0suvpWXoOCn2K1/fcE2J4utnU/9m1Pj5si+/q+KDkjg7uyvNZUO7S3/0epy/d8AAAD//5akpX1EKgAA",
+	"H4sIAAAAAAAC/+x9+3PbNrrov4Lh3Zm0dylZfrTduLNzJ7HTxts8fB1ne3YrHxUiP0moSYALgJLVHP/v",
+	"Z/AiQRKU5Dwcdze/tI5IAB8+fO8H+C5KWF4wClSK6PhdJJIF5Fj/eeIePAecyYX6KQWRcFJIwmh0HJnf",
+	"EZshjASh8wxQNVkURwVnBXBJwEwssSxFd5JqFWTeiNE4YtfjCDGOxhFwzvg4GqLLBSAOWDCKcL0KImJM",
+	"S7rQgKwREShj8zmkMaJMIg6y5BTS4ZhGcQQ3OC8yiI4jdh3FkVwX6m8hOaHz6PY2jjj8qyQc0uj4Fwft",
+	"VfUem/4GiYxu4+iZAqm7j4sfTtB3fxl9p4HLCKYSaeARB1EwKqCDkRQkJlkArWWO6YADTvE0AwQ3RYYp",
+	"Vg+RKCAhM5IgyZBcEIFYkpScA02gsUOFrUcU5/AIzQhkqcKM2x6alhKtsNAoKjhbkhTSLkLiiFAhsZq5",
+	"A+HbizPEYQZ6YTRj3ABTQWc23gPbnn4q9vYPDuHom2+/G8BfHk8H+wfp4QAfffPt4Ojg22/3j/a/OxqN",
+	"RlEczRjPsYyOo5KTQbVoCN4+Cnt+eXluiQslLG1AczQaVTMRKmEOXE0licwC+36zYFyiRfN8RJnnmK8V",
+	"G8gFKIxOM8gbWz6jS5yRFJ3RopQh0M0Pm9FMUqCSzNaEzvVCBsl6pL/WQspCHO/tpUk+tL8OE5Y7rBMD",
+	"yoBYUHZFb4s/7LIGTyEu2SIz7HEYqSEZVVzCSp50uaQpnnCaEjUTzs4bb/2Jwyw6jv7PXv36nhVle205",
+	"dhv3yjHAycKTLtewVvyy9n5STBUF9lvg0G5PMGWUJDhDBTYryAX4e/XOzQCn0IzT1zRbR8eSl3AHOn+9",
+	"BI6zDC18FB9bcarEagpzjlNIxxFaLaApSNkM7ZmBYzrDJBOxEcCUyYmCaF0PUv8kFIRAyQKSa6Rf303E",
+	"NtEWRzcDDMWgwsfxO4VHCZwKRWQWJVdxVGQlx1mFJRHFUUU6Dk/qhzLD3MelgwD4kiRghR0fKt4gzO5X",
+	"A2ZI4NkSqAwgtpQJy0HhiFFw+NWb75IrcDlRSMMzCQE18bPCoSIC9aISlljqiTFFmnMR0LRghEol9wkH",
+	"4fNoiiUMJMmD4g/CeunnxdqsV51VWNqbXU16hWiTbyXjkCK9x3r2BgVoogkuJGXRu8ybWkw7brHodnh5",
+	"JCqNGiM8FUClIUwiUUpSq/jVC6kPz0FY0GdYAk3WkzwAy2nJjdZtAqLEIAiJCEU5yTIiIGE0Ff5i+wfe",
+	"oREqvz2Kwlomh00Uok+MY7ozCawwp+rPzpxPnFpCcoElShkY9a/IoV4rRqJMFggLhBvkiaeslEgyS5Lb",
+	"lYMBr0lRDVz3awzNgi+I0GzYZC1YOi1AJORbxb43X61iI8w5XncgtlOHwHqJ1YlRTBP4mdCUrbrYPc8w",
+	"pZCivH4VFcAJS4foB81wDZkhUFpyp8VXek6UY36t/j2mTkipY/AnJFRIwKkixoSVVOoJ2ArzVKBKSBtL",
+	"t4U2mk7ClPaMpo62/ZUMSLHlbiExlxN7pLuRoTckxOBc9q+64xpda72Gsdpv6DTPLXa7gP1QZhmqkO9U",
+	"EuJQcBBApRYFHeTiUi62EaJb80lpjI9Eya6klGQJE8WAJYeA9HlV5lPg5rir99EsQE6xghakslOw4uAE",
+	"hJiVWVcmj3qNC08oJRywhEnYnjlXVgwuCqAppMYJgVpjSYbMaORcBzFEz/JCru3vQr2fIyzHtDGQSAHZ",
+	"rO2m7S3395a5CFGYBTJMYpckByFxXhi9YI1yc67K75kRLiTiMCdCAoe0j+a2GmIpTMv5RHmcldCd4TKT",
+	"0fEMZwLaZuYLNrfmn1YgAmnd5Ty5mu9pqhBSaTmBCEXGV4zRiigDLeGgnQGcqddSnEjl8nKYY55mIITj",
+	"r3nGpjhTPjHKYAmZRrDdxpSxDDA1+8jg/Y/cjEaYVqceK8RzQO9Ieqs9dA5FhhNjSvvH8UhUY9DZqaOV",
+	"HDAVaE+N7iEJ/SxEFykRRYbXE22lb3Os7cvapLdObA1aY9mfyin8nXCJ3hhDEp3Xb3VgcLjpETHuMXp7",
+	"8cLiqUGhT87PEBEIazYm0yzs3Yliv+Hd4YLsLfdxViywwlDLsQuBOQf5vieuMLjhvJE77jH91OdtTQwt",
+	"6SYpEepc0+2c+OaaFC2VXAUxHKyx/kkZLySpfhSaAZU1hMe0ZZiaGFV1juoMsxVea3QwLiFFnoruMqHd",
+	"CtwUoNjZmk0hzXBhBYMf0xDGsNOWgbIcbERsiLQ1CQrPMcJ0jQ5ubuy4MVVmYAvjvyg7+WB0dBXXRlaO",
+	"b0he5tHxN48fx1FOqPnXfk/opGFnVfuqSc0eTLRXeWl3IT91KI2TG6IndoPGxza/Gltqm4IxE/3eoSzl",
+	"mLJcIaCQa0tDd3WR6nCQpodjc/pxbavFTfPHqirPALS6Hjf262xHpSFCRhvjqKTXlK2oCoxKa94bldeY",
+	"x1h3RgsaP6eFHefAbVWDJA2Erij5V1nFrAhwy2AQEqH1mneOCZYk3QlEK3UmmkE2GVs2VKDjarUZEyNG",
+	"szUSYH3NjAhtgCtpgAhNsjK1c4so4Pptt7oyPIVsY4QrYH34O/gJ1ntLnJWAzFQa33POykLBWcmvGMFw",
+	"PlR6WJ288m05koDzIfoJ1kLZHmOqpxEIc0D7iv++PUQZSKlHp2ROpIjRo+GjGD2aqP/sPVKTPBo8arHX",
+	"u8gsoY5JDAALHfkEnOudzziObgMGukfTk1Xlb20yr7sOmpoGJE6xxLva5i/d+7dxFDYeLEWrh463N5Ly",
+	"dTmFJeFysH9wGFJdFG7kpKG/toQDfM2iBqO0NGZLU0DESEkuqYwtmDEOHv87h+D9rF1FjFpOBESfcth1",
+	"eKx6B4mysGqvzg94vNVQOtaej5wpGsVRWSjgIl8P9UTNa1UTNmcunCunHnsSqXFaFYPsmpbYHqXVFDZZ",
+	"Ahcajo4jrJ8j+9yRVEP8aBo7d5hsRJgiZ+xFsQuZRsfRfy9/GQ0eX/35K/3sf6Yg8df/T//0f/8UdNTN",
+	"apNw+uFSweCLxIabwmYz4C2Y8rukZS481YOqIBFQZVv8EjXcM0MMaXTlr9Z4Y+txKCpnpZy4aF3Qa1SG",
+	"naLWBGeZ6HhmMWJL4JykLnZj3Suf+8bULjREI6TMN/9F++h75CTslC0BHY5GWtYmGc4LY/EcjkYtYXo4",
+	"8iyvoN1lcPSeLnGGhUQOy+8rIHpJ/e9NGve8AQFS6VERKyXKIQcqjZcIS+BrC5ExYsdUgESMIkzt77Ge",
+	"zfxt3I7ftOlstPLR6DEizQXRAosxnQJQlLNUCYEUCUI1aWPpWLGN+S6yW9EnmxKq/L4WX3VEwdVd8yC1",
+	"cHrn/pwoh8hPjFTvRI1MSNF1VMO5kOrFWy9K9qQMJrW86IMJvyt8mxNTnONs9ppvjIWk2KZpuo+pcpkk",
+	"uwZqtNoSeJW1/14rMkT0odtTlgxdAxRGVJocBKMQinouACs09QQC9EOUYM6rdKqGIq6z5M5eNRN186z/",
+	"NXhSkMFPEMxx6MkCbKh3KplCW+qtpZnSyTMHkBfkCTgoK04k1OzYm0OeAubA9YICjSN1ooyT37XUPUZP",
+	"zdNxORodJhpo/SeofKHduBlZYQgpFlL/MsYmmyEP02PqSXCzsskDpMCjq/YmgjnlTUHbU20cnHNYEliF",
+	"LCUsTTRKoRB7Eo6VWYpSFle0ZWKlKV8jXnZpZ5u38CboI7TFm4qKEJVO4YCuoaizVCoipsF0omrGeAJN",
+	"qTPaKYFUeNHsXazcDso98dDa9KZzMI7uieLhCxPLDASRqoiJDhiFk6UkpIjPTjuobE1SWYRtP7DDhzm+",
+	"OTMv62hFTqj755aEkIJsZxQIHdDYkDW2oCuyZLQRY2ziY0sK1/cA6qInZa9oAqdMoqlN5X2yHG+VcyWz",
+	"+kcPAF426HhDGtiAH9otyAXwtqnQira5HC+kXsojWwdDa6EAhTtIdHYaCChsFlQkvRtt9KPaYI+bt5Se",
+	"rLLLjIcIhNfz7ZQD7SfXbRlRt9Kmjbo0bTt+NycUS0h1lESRv2+bNPejneACz2GyUWPOdBmd5ASWTjuq",
+	"kUiNNPxlgPUJD9Z/K/55cvbt2W/P1i8P3o5eXf7j8MXPb49e/3wmX17+7frlen/x6vTtwYvL/79+9ds/",
+	"bl6dPjt8dfpk9fLkb49D9Fpv4q7ID7mqkkmcTQT5HTYFo6o1UY5lsnB7n5FMqh9xwplQYeZMY0LUrv/K",
+	"GMzXpJjUKyHtgsumojnapVThdgMRvPQiLeHYlXEXWgUJ1ZvIhWpcmcFig4A0EaWJruLr4O1HYHOOiwVJ",
+	"XHBLvRfKsZgwBTQJxgapBvuh03em+dZDd9GGE1zghMj1Jg/4RJdHyjpqgrOe6HGgUiwoVH9nNICYJ0tM",
+	"MjwlGZFrpF5ROkOhPAEqgffFROo3BtMdqrk8mjhX1LpB5uqaVK3UkwWmc0CEKnsNc2XtVl7fa0vM9m3M",
+	"AWUwkyqubYalJuXiPde1jVb2XIP2FpHSC9WcbwU0fxpTbVrkyhMPlyQgxtv2+HD8kSoBPmquPdotRf1x",
+	"U9L3lFBOGJWYUCUB+3KB/XnXp1iATreyWSfX+slToztAviGLedes5X2mFqu8m9bzOEmgkEIlGce0zjIa",
+	"VvlUucT7zBt2ju2DczYv9AQ2Xe6Uu2fymgU8QI2kteJKw5o3YPvoaZSdUyHFhoKIbZHwv28IgZuxHx67",
+	"1tHqbgFK9Gkixd/3B3+jbXHcrWhqSLC8FBIJSVTZN156ZSxKAyu4cFH4XtFGk65ju/TnUhL7CiLUWI+h",
+	"4jhjeiZFGbLrJc7QyflblDAOAmFjojSrXXpqds20OeSMr/tmNk/D00b7l0/DQTs1Lw3almZWWlnm6q1m",
+	"ye8mWJUrjee909rHPdAehKANHZ/JKL3Z0GeFORGMoinIFdg0gDLyplg4TtOq3ufCnKWQdR03uJEcTxKW",
+	"lTkNLqYfIFs7iUhrMdWCRJlEuZLXJhpH12atWOkYqbAwNNM3M4WVSzTMYI6T9URbf3fLExI6EWuaBI6D",
+	"l7YSgzIDgzDhFbMbzAHlRAgdx+FI48CHztYadRWwHbQdX3WPlpFTBvuuaFujDG6IkDsjqRnxuROWHNAG",
+	"DwFk6d93BrkHwrvA1A7D2GO86uUFKzLDcYpmAradsO6PWTTVmeWGamNVXvb999VeoLu/W03EM2b6o6jE",
+	"idpfx744PXnZqVHQ9YUD1Ei+Kp7PMcVznYBT4q09yqRqiNCjidqrelMEqyAaNUVolrGVItYUZoRCaiXB",
+	"mCrYgC4wTcyiCsdM4MzYMxlJgAotMI0JEj0pcLIAdDBUyfeSZ14t5Gq1GmL9eMj4fM+OFXsvzk6evXrz",
+	"bHAwHA0XMs+8xr4ohJbIU771OZrKB4oLEh1Hh8PR8Mik3Bf6ZPdwmhNqjahjE6A+fhfNQfZJYONmJtb1",
+	"b4tfnQ/w2KgUNWdZkTym6rxMUWEljgyTxtW/K5FF0+pvylDG6By4Fbtj6svdIbowNGhOVe/LpHzMkVRB",
+	"irNU7UXt9I0zziq/UG36YDRydGm7qZQNQhI9eO83YWwbs99tRmlDpWmyD/KwQYY6qqPR/obFbRPMn+8G",
+	"hGn4Daz+stYFtq3SR5sB5/D+wHmi17b1w5UbeRvXNZf3Bclb6nxN06WqRZztk3XE0yb9KI4knut8t0Zi",
+	"dKUGNfkrJ3OOpcmPs5BMf6KsXU2/lpMhRWaQFvENG2RhW6AFAEVrsDpVySZn++g2KSxNC0xZ2GLaX3GW",
+	"sdUkBSF5mUiyhF9tZDX2WG1MG7zWMHG0IZFyVhQuv6LbeIZjehcWfGmQUTFhgTnOQeoI9S+dXjbOigo6",
+	"rZsVDG2J0IBS5wSj4+hfJXDlQVhB3Nl9VeAQqrtu20K3Vw9FWhjE19TxRXr8QaSHpfsd5cei6kMP6uRG",
+	"Hk7ZfX02kwk1M9tibcNjRNju6jHVXoOtgfFup3DRMq2IGx3YTC6Ar4iA7201i2kEHtNWD/pXbp+xnQvl",
+	"jBLJ+Ne21cLYrISiX2sk/xoSGD+C64D/hDz43PVUd4719U+tg3ze7KN2p+e6vvXxZWQJv/ee3oXevRVo",
+	"Ni6SgNAHo+T1mdfqao5YSbcUdIyQJusYCaY6OVSlvWLFiqSqUXOQDXeYg1YGKvVgSUutpcs7VGEMpghn",
+	"BDsRmwlmT0g0Drl1z0DPYb0gS6AgxOc5rss2Olun56DbfH6NrGnPGcqSK0u1ShfXhZ0V2pv9N4gVNltm",
+	"MqGmIqqJP+Xznfs+3Cbt+IOexltlum4XK4dUYaO8L+Dn3ca7LNS4rqFnpXYrdb1kXS9r6iyq9pKoEQRW",
+	"TpNpCwnWYXVqV1S3gymW8oBdYJ1+VwLQxGqF6zbQtlPC8hwPBChUS+VeXMP6r6ZMrMCk6j0Y2yzuX23C",
+	"NZaA87/qjgAlSHswYNb5QGyvFkzYuLVNywgTuJVwI3sWVv+buLfvtv5Lk+zwwoa2UAEVCiw8hyE6NdpV",
+	"x5B1mk2gxNhiWNbWqE4HJIzOyLxUfKHGIpXTFzHaH41QSXXbp/dGpV6GvSjN8Y2pvVATNXZW52VCgeP+",
+	"Go3ClH4Q1rekV+pxJ0yemkCSyUGZNK2th/A6WyRDQsXAMdKrIkZRhvkcrHPcA1KrSOJu1mwH0DeM29qd",
+	"Y01nMfJalXVzVl2mHVdyLFujGVN2tTbBxxSLRL2rpq6YRlOt+mUcNanGW2CInqAaxWNKhOlbMtZlFVvQ",
+	"8E2ma0TMRVBEiNKEvfqJxY1pCp8qSF1BF+0gXc5Mw5TRgl6+i27pwuoBrdN/9UDckUaZVMgdqVv1K91l",
+	"zP7R/RnbT3Hqsv8P0dh/oVPL3s0MwrM03G/R1W3cExC4sLYEwojCqmNP1ByJMDVxalukqZ1xL45JBCIp",
+	"5AVTODke0wE6mxlNUtmKLjKvV3pzjoBKru+kMxya+oP0u9aaETiHPcoqoM5OY5u7tuMNhL3jU6KTm1Q2",
+	"ZmhGYTHJBPpK6YeMJPJrO1X9fs+ERoRtmUpHRVzlzaTOnduYiJ+GfyR8s1mREqS2OU13so2pwp1/OtiW",
+	"CKXVHHGVo+KtA6oaQMgMEanFX0m5kjI6UxIKY+qT8fr3NpqJr53ZWdTFoxrumjIaMPXJq7Qho7bWngbK",
+	"qKbQh1bb9Oc1E/RA0Tmw9xCcWmw8Zen6o8tMIyrqrIjtcfjksjokotwzx4foKw4D/5y/VpLzYLR/v9BY",
+	"qYK+UizTAedelYi739BcKqhXf3x/q59YUYQGtiSF+4INZ9odQoSiUoAG7uDg/oC7bBQhmY5+y3pW3T80",
+	"leupzNB1J13d2/Dz6xa1s/TWSJIMJIQ0s665xF2dPOMs9+X7unuzhmAz2+oDqWlaTzBFU0BTzsr5QqIp",
+	"Tq6NMuOgGxncFrSJ7GbSURpXPSPqBFursceZ0VXTzsL4O4krqQ6pFtestJtq6d6X0K5VrAojnTzXVXC1",
+	"Z1VhPWrLzQ9SNmYbTVBgCdTo2C7ydjPZNfI+0NW6KE1RidDuvKnewzbHo4GOq8ta6qYwupbKbYwN0dgI",
+	"x0qRgWkSm7rBaa8nlPL1xHS5PDA/o9keF2D8U9PvhgoshOqvbLYBt/ZvdNrRhipu+2Kz/+ZzqZ6zU13A",
+	"kRED+dHo6P5gqDCir1RkJU3vXQdWIGxlyAeocayMqVXBZn0ThwPJP4IMaRMd5lCOgBawZ6cdQf0jyI8m",
+	"pT+pbL76TPZvME7h2no1JM8ucaC54LJZzexuusBC+9pUErlGEs9j1xCtnpzNBi916WrV87055nr7Rd44",
+	"efPQuNrwY7GFlYtwq9BbffbG7JJ1j0+rntQ1S05Zuv6+an6z714DFLpVnfCq3EpnI7yCejXQ5jlEUx3W",
+	"PUZj+rOyI6tKwditrgcz7pNt7BVft2+kGFN1JUX3/g0lqwN3UoRsSt1X9VFNSsNj92tSfkAhe6yzrFUX",
+	"P6FatjvJog9+iC7xNWhSSSAFmpisvV8UhAgdU3eC7UvhxtHhOHIIMTKuRok7540yaefQSA58DgO9wz+/",
+	"n7jWBPGgYyUPwjj04hL/WWZhOzTSDIaYci+dKfQvOeLd+4E61+UAmhPlBVqOeoj659y0k2brKsK/Yzwj",
+	"jhS19KkkP0/QNjbthTZe9MmqCt1hpkWcTlw2JvEGb4ykV2Hv6nejoYRROioKWMeDx7Qvzu5mCdybZFz6",
+	"7VFzXWX5h1dDJozezS90g+la9bj+vCaoHzeo/mGqsr4j6d9QV/4bphG+qMY/uGoc011045h+lqSDrw/+",
+	"GImHt3dT0/1pByu+B/UHQzZWHqoTY/VlTa0m7JwJiTgkQGX7swsUViDkmGrFOkSvnbOqb1N8/uzJi8vn",
+	"k5Pnz05+mjw/e3P5+uIfkzdn/3xmh1dloPUtCxxUzVwRTCf49Yzel03Eww5Z7VASZw7J3J2gzqOv/o/k",
+	"pEebHozi+l4Bd89Xf/3a1Scvp62/YfNQq36c9Pcqf75EsHqKj3xhEJIDd5VOpkuw/j5eT+FSSds3v3Oi",
+	"Mpt4hdd1ZmuFiXYi2p+veCR0CpTNZmNKqAS+xFmMSuGs2Dpp5nJm5oNFJHHtBe6Tm6pgtb65c0zr1gd3",
+	"QRxNLeu6L20GWgWboutLnD1wIdtt33cJ/UvivnBqT82CQZL3mTb9VdriPS2IY1s10M+fTxWxaB5DuFVQ",
+	"sNLtziaJ2maGi2Y1wnszgp3/3zvx5HWUW1nzH59qPm2d+2d0oBo1OdqFqjIZEl8DDblQD1N2aOJCOMRU",
+	"26TG8XT9yt6R9P5JaVNlq78GiNde3ZH+HK+9yYrw+sswPf1ijl2eGpC2iJZX4dub+lpgNgqQnNAXQOdy",
+	"4VvcX/LWDzFvrRn1S+Z6h8x1iGktJ2yVCh9u4muLXscH2MyLKXntTpUxP6bbrXlTNG8EnQzY9fVtfS4q",
+	"wqh7S03mPEVPOm619YXXbfzpQryBy8g/U9A3cO9ziAWAD+rcf+Aa6M8fCv5jWPcCdDP+ts4gxZg6sru9",
+	"jXzl3TzuuD/BVP9d35F63LxGRCfQbLLMXM2jh+st+3ePYJtIIqp33DHZN6PDui2vvvpXzTem3sfZm82c",
+	"Hc1/4b7I/vlaxR2+DDpSbZ1/Mzq8/9XN9781BC0iany1vq9h3aw38G/32ho8Fq2bxCqKRLYq3etoV6c9",
+	"bFy/JQzVWKtBv6CvKTCWc/MyME1FLl/bFyZu3H0mPv31TP4tazsHPLuBNo3J6g62Fk57eNveA+osXHMv",
+	"WONjqNHtVTW07zselVXiX4JWf+2s405H3bh2I1QTGmuJrDtS37tiiWEJwbHmPpPbq9v/HQAlmLH8rYcA",
+	"AA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -17,6 +17,27 @@ const (
 	Updated    ProviderStatus = "updated"
 )
 
+// Defines values for ProviderAuthType.
+const (
+	Bearer ProviderAuthType = "bearer"
+	Header ProviderAuthType = "header"
+)
+
+// Defines values for ListProvidersParamsHealthStatus.
+const (
+	Maintenance ListProvidersParamsHealthStatus = "maintenance"
+	NotReady    ListProvidersParamsHealthStatus = "not_ready"
+	Ready       ListProvidersParamsHealthStatus = "ready"
+	Unknown     ListProvidersParamsHealthStatus = "unknown"
+)
+
+// ComponentHealth Health of a single component
+type ComponentHealth struct {
+	// Status Component status, "ok" or "error". The reason a component is
+	// unhealthy is logged, not returned.
+	Status string `json:"status"`
+}
+
 // Error RFC 7807 compliant error response
 type Error struct {
 	// Detail Human-readable explanation specific to this occurrence
@@ -37,36 +58,130 @@ type Error struct {
 
 // Health Health status singleton resource
 type Health struct {
+	// Components Health of each component keyed by component name
+	Components *map[string]ComponentHealth `json:"components,omitempty"`
+
 	// Path Canonical path of the resource
 	Path *string `json:"path,omitempty"`
 
-	// Status Health status
+	// Status Overall health status: "ok", "degraded" when a component of /health
+	// fails, or "not_ready" when a readiness check fails
 	Status *string `json:"status,omitempty"`
 }
 
+// HealthEvent Outcome of one health check
+type HealthEvent struct {
+	// CertNotAfter When the certificate of an https endpoint expires
+	CertNotAfter *time.Time `json:"cert_not_after,omitempty"`
+
+	// Error Why the check failed
+	Error *string `json:"error,omitempty"`
+
+	// HealthStatus Health status stored after the check
+	HealthStatus string `json:"health_status"`
+
+	// HttpStatus Status code of the health endpoint's response, absent when it did not respond
+	HttpStatus *int `json:"http_status,omitempty"`
+
+	// LatencyMs Duration of the health request in milliseconds
+	LatencyMs int64 `json:"latency_ms"`
+
+	// Time When the check ran
+	Time time.Time `json:"time"`
+
+	// Warning A problem that does not fail the check, such as a certificate about to expire
+	Warning *string `json:"warning,omitempty"`
+}
+
+// HealthEventList defines model for HealthEventList.
+type HealthEventList struct {
+	Events []HealthEvent `json:"events"`
+}
+
+// MaintenanceWindow Planned maintenance period. Failed health checks during the window mark the
+// provider as maintenance instead of counting towards not_ready.
+type MaintenanceWindow struct {
+	// EndTime End of the maintenance window, after start_time
+	EndTime time.Time `json:"end_time"`
+
+	// StartTime Start of the maintenance window
+	StartTime time.Time `json:"start_time"`
+}
+
 // Provider Full provider resource representation
 type Provider struct {
+	// Auth Credentials sent with every call to the provider, including health checks.
+	// The token is never returned; omit it on update to keep the stored one.
+	Auth *ProviderAuth `json:"auth,omitempty"`
+
+	// ConsecutiveFailures Number of consecutive failed health checks, reset by a successful check
+	ConsecutiveFailures *int `json:"consecutive_failures,omitempty"`
+
+	// CreatePath Path appended to the endpoint to create instances. Empty creates them at
+	// the endpoint itself.
+	CreatePath *string `json:"create_path,omitempty"`
+
 	// CreateTime Timestamp when the provider was first registered
 	CreateTime *time.Time `json:"create_time,omitempty"`
 
+	// DebugLogging Log the requests sent to this provider and its responses in detail, with
+	// credentials redacted, regardless of the global log level
+	DebugLogging *bool `json:"debug_logging,omitempty"`
+
+	// DeletePath Path appended to the endpoint to delete an instance, where {id} is
+	// replaced by the provider's instance ID. Empty means /{id}.
+	DeletePath *string `json:"delete_path,omitempty"`
+
 	// DisplayName Human-readable display name for the provider
 	DisplayName *string `json:"display_name,omitempty"`
 
 	// Endpoint Full endpoint URL where the provider API is accessible
 	Endpoint string `json:"endpoint"`
 
-	// HealthStatus Health status of the provider
+	// GetPath Path appended to the endpoint to read an instance, where {id} is replaced
+	// by the provider's instance ID. Empty means /{id}.
+	GetPath *string `json:"get_path,omitempty"`
+
+	// HealthCheckDisabled Skip health checks for this provider, for static providers without a
+	// health endpoint. The provider is always reported ready.
+	HealthCheckDisabled *bool `json:"health_check_disabled,omitempty"`
+
+	// HealthExpectedStatuses Response status codes that count as healthy. When empty, any 2xx status
+	// does.
+	HealthExpectedStatuses *[]int `json:"health_expected_statuses,omitempty"`
+
+	// HealthPath Path appended to the endpoint for health checks. An empty path checks the
+	// endpoint itself.
+	HealthPath *string `json:"health_path"`
+
+	// HealthStatus Health status of the provider: ready, not_ready, maintenance when the
+	// provider failed a health check during its maintenance window, or unknown
+	// until the first health check after registration
 	HealthStatus *string `json:"health_status,omitempty"`
 
 	// Id Unique identifier for the Service Provider
 	Id *openapi_types.UUID `json:"id,omitempty"`
 
+	// InstanceCount Number of service type instances, only set when listing with include_counts
+	InstanceCount *int64 `json:"instance_count,omitempty"`
+
+	// Labels Key/value labels for grouping providers, e.g. by region or team. Keys and
+	// values are 1 to 63 letters, digits, '.', '_', '/' or '-'.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// MaintenanceWindow Planned maintenance period. Failed health checks during the window mark the
+	// provider as maintenance instead of counting towards not_ready.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+
 	// Metadata Additional metadata about the provider
 	Metadata *ProviderMetadata `json:"metadata,omitempty"`
 
 	// Name Unique name of the Service Provider
 	Name string `json:"name"`
 
+	// NextHealthCheckTime When the provider is next due for a health check, omitted before the first check
+	NextHealthCheckTime *time.Time `json:"next_health_check_time,omitempty"`
+
 	// Operations List of operations supported for this service type
 	Operations *[]string `json:"operations,omitempty"`
 
@@ -82,18 +197,85 @@ type Provider struct {
 	// Status Registration status
 	Status *ProviderStatus `json:"status,omitempty"`
 
+	// TimeoutSeconds Timeout for calls to this provider, overriding the global health check
+	// timeout. 0 uses the global timeout; values above 300 are clamped to 300.
+	TimeoutSeconds *int `json:"timeout_seconds,omitempty"`
+
 	// UpdateTime Timestamp when the provider was last updated
 	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Version Version of the provider's settings, incremented by every update. When
+	// set on an update, the update is rejected with 409 if the provider has
+	// been modified since that version.
+	Version *int `json:"version,omitempty"`
 }
 
 // ProviderStatus Registration status
 type ProviderStatus string
 
+// ProviderAuth Credentials sent with every call to the provider, including health checks.
+// The token is never returned; omit it on update to keep the stored one.
+type ProviderAuth struct {
+	// HeaderName Header carrying the token, required for the header type
+	HeaderName *string `json:"header_name,omitempty"`
+
+	// Token Token to send, required when registering the credentials
+	Token *string `json:"token,omitempty"`
+
+	// Type bearer sends "Authorization: Bearer <token>", header sends the token as
+	// the value of header_name
+	Type ProviderAuthType `json:"type"`
+}
+
+// ProviderAuthType bearer sends "Authorization: Bearer <token>", header sends the token as
+// the value of header_name
+type ProviderAuthType string
+
+// ProviderDeletePreview What deleting a provider would do, returned by a dry run
+type ProviderDeletePreview struct {
+	// InstanceCount Service type instances of the provider, which are kept when it is
+	// deleted with force
+	InstanceCount int64 `json:"instance_count"`
+
+	// Provider Full provider resource representation
+	Provider Provider `json:"provider"`
+}
+
+// ProviderHealthCheckRequest Providers to recheck
+type ProviderHealthCheckRequest struct {
+	// Ids IDs of the providers to recheck
+	Ids []openapi_types.UUID `json:"ids"`
+}
+
+// ProviderHealthCheckResult Outcome of rechecking one provider
+type ProviderHealthCheckResult struct {
+	// Error Why the provider is unhealthy or could not be checked
+	Error *string `json:"error,omitempty"`
+
+	// HealthStatus Health status stored after the check, absent if the check could not run
+	HealthStatus *string `json:"health_status,omitempty"`
+
+	// Healthy Whether the provider's health endpoint responded successfully
+	Healthy *bool `json:"healthy,omitempty"`
+
+	// Id Provider ID
+	Id openapi_types.UUID `json:"id"`
+}
+
+// ProviderHealthCheckResults Health check results, in request order
+type ProviderHealthCheckResults struct {
+	Results []ProviderHealthCheckResult `json:"results"`
+}
+
 // ProviderList Paginated list of providers
 type ProviderList struct {
 	// NextPageToken Token for retrieving the next page of results
 	NextPageToken *string     `json:"next_page_token,omitempty"`
 	Providers     *[]Provider `json:"providers,omitempty"`
+
+	// TotalSize Number of providers matching the filters across all pages, omitted when
+	// skip_total_size is set
+	TotalSize *int64 `json:"total_size,omitempty"`
 }
 
 // ProviderMetadata Additional metadata about the provider
@@ -112,6 +294,63 @@ type ProviderMetadata struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// ProviderPatch Provider fields to change in a partial update. Omitted fields are left
+// unchanged. The fields are validated like on a full update. Use a full update
+// to remove the maintenance window or the credentials.
+type ProviderPatch struct {
+	// Auth Credentials sent with every call to the provider, including health checks.
+	// The token is never returned; omit it on update to keep the stored one.
+	Auth *ProviderAuth `json:"auth,omitempty"`
+
+	// CreatePath Path appended to the endpoint to create instances
+	CreatePath *string `json:"create_path,omitempty"`
+
+	// DebugLogging Log the requests sent to this provider and its responses in detail
+	DebugLogging *bool `json:"debug_logging,omitempty"`
+
+	// DeletePath Path appended to the endpoint to delete an instance, containing {id}
+	DeletePath *string `json:"delete_path,omitempty"`
+
+	// Endpoint Base URL of the provider API
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// GetPath Path appended to the endpoint to read an instance, containing {id}
+	GetPath *string `json:"get_path,omitempty"`
+
+	// HealthCheckDisabled Skip health checks for this provider
+	HealthCheckDisabled *bool `json:"health_check_disabled,omitempty"`
+
+	// HealthExpectedStatuses Response status codes that count as healthy. An empty list accepts any
+	// 2xx status.
+	HealthExpectedStatuses *[]int `json:"health_expected_statuses,omitempty"`
+
+	// HealthPath Path appended to the endpoint for health checks. An empty path checks the
+	// endpoint itself.
+	HealthPath *string `json:"health_path,omitempty"`
+
+	// Labels Labels replacing the provider's labels. An empty object removes them.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// MaintenanceWindow Planned maintenance period. Failed health checks during the window mark the
+	// provider as maintenance instead of counting towards not_ready.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+
+	// Name Unique name of the provider
+	Name *string `json:"name,omitempty"`
+
+	// SchemaVersion Version of the service type schema
+	SchemaVersion *string `json:"schema_version,omitempty"`
+
+	// ServiceType Type of service offered by the provider
+	ServiceType *string `json:"service_type,omitempty"`
+
+	// TimeoutSeconds Timeout for calls to this provider; 0 uses the global timeout
+	TimeoutSeconds *int `json:"timeout_seconds,omitempty"`
+
+	// Version Version the provider must still have for the patch to apply
+	Version *int `json:"version,omitempty"`
+}
+
 // ResourceCapacity Resource capacity information
 type ResourceCapacity struct {
 	// TotalCpu Total CPU cores available
@@ -127,30 +366,131 @@ type ResourceCapacity struct {
 	TotalStorage *string `json:"total_storage,omitempty"`
 }
 
+// SchemaStatus Comparison between the database schema and the service models
+type SchemaStatus struct {
+	// ExtraColumns Columns present in the database but not mapped by any model, as table.column
+	ExtraColumns *[]string `json:"extra_columns,omitempty"`
+
+	// InSync True when no tables or columns are missing or extra
+	InSync bool `json:"in_sync"`
+
+	// MissingColumns Columns required by the models that do not exist, as table.column
+	MissingColumns *[]string `json:"missing_columns,omitempty"`
+
+	// MissingTables Tables required by the models that do not exist
+	MissingTables *[]string `json:"missing_tables,omitempty"`
+}
+
+// SchemaVersionList Schema versions supported for providers
+type SchemaVersionList struct {
+	SchemaVersions []string `json:"schema_versions"`
+}
+
+// MigrateSchemaParams defines parameters for MigrateSchema.
+type MigrateSchemaParams struct {
+	// AllowDestructive Drop columns that are no longer mapped by any model
+	AllowDestructive *bool `form:"allow_destructive,omitempty" json:"allow_destructive,omitempty"`
+}
+
 // ListProvidersParams defines parameters for ListProviders.
 type ListProvidersParams struct {
 	// Type Filter providers by service type
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 
-	// MaxPageSize Maximum number of results per page
+	// HealthStatus Filter providers by health status
+	HealthStatus *ListProvidersParamsHealthStatus `form:"health_status,omitempty" json:"health_status,omitempty"`
+
+	// Labels Only return providers having all of these labels, as comma-separated
+	// key=value pairs, e.g. "region=us-east,team=infra"
+	Labels *string `form:"labels,omitempty" json:"labels,omitempty"`
+
+	// NameContains Filter providers whose name contains this text
+	NameContains *string `form:"name_contains,omitempty" json:"name_contains,omitempty"`
+
+	// MaxPageSize Maximum number of results per page. Defaults to and is capped at the
+	// server's configured page sizes, 100 unless configured otherwise.
 	MaxPageSize *int `form:"max_page_size,omitempty" json:"max_page_size,omitempty"`
 
 	// PageToken Token for pagination
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
+
+	// SkipTotalSize Do not count the matching providers, to save a query on large tables
+	SkipTotalSize *bool `form:"skip_total_size,omitempty" json:"skip_total_size,omitempty"`
+
+	// OrderBy Sort order: name, create_time or update_time, optionally followed by
+	// asc or desc, e.g. "name desc". Defaults to create_time. A page_token
+	// is only valid with the order_by it was issued for.
+	OrderBy *string `form:"order_by,omitempty" json:"order_by,omitempty"`
+
+	// IncludeCounts Include each provider's number of service type instances
+	IncludeCounts *bool `form:"include_counts,omitempty" json:"include_counts,omitempty"`
 }
 
+// ListProvidersParamsHealthStatus defines parameters for ListProviders.
+type ListProvidersParamsHealthStatus string
+
 // CreateProviderParams defines parameters for CreateProvider.
 type CreateProviderParams struct {
 	// Id Optional provider ID for idempotent registration
 	Id *openapi_types.UUID `form:"id,omitempty" json:"id,omitempty"`
+
+	// ValidateEndpoint Probe the endpoint's /health before registering
+	ValidateEndpoint *bool `form:"validate_endpoint,omitempty" json:"validate_endpoint,omitempty"`
+}
+
+// DeleteProviderParams defines parameters for DeleteProvider.
+type DeleteProviderParams struct {
+	// Force Delete the provider even if it still has service type instances
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+
+	// DryRun Run the same checks as the delete, without deleting anything, and
+	// return what would be deleted
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// PatchProviderParams defines parameters for PatchProvider.
+type PatchProviderParams struct {
+	// IfMatch Version the provider must still have for the patch to apply, as
+	// returned in its version field. Takes precedence over the version in
+	// the body.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ApplyProviderParams defines parameters for ApplyProvider.
+type ApplyProviderParams struct {
+	// ValidateEndpoint Probe a changed endpoint's /health before applying the update
+	ValidateEndpoint *bool `form:"validate_endpoint,omitempty" json:"validate_endpoint,omitempty"`
+
+	// IfMatch Version the provider must still have for the update to apply, as
+	// returned in its version field. Takes precedence over the version in
+	// the body.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ListProviderHealthEventsParams defines parameters for ListProviderHealthEvents.
+type ListProviderHealthEventsParams struct {
+	// Limit Maximum number of events to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetProviderByNameParams defines parameters for GetProviderByName.
+type GetProviderByNameParams struct {
+	// Name Name of the provider
+	Name string `form:"name" json:"name"`
 }
 
 // CreateProviderJSONRequestBody defines body for CreateProvider for application/json ContentType.
 type CreateProviderJSONRequestBody = Provider
 
+// PatchProviderApplicationMergePatchPlusJSONRequestBody defines body for PatchProvider for application/merge-patch+json ContentType.
+type PatchProviderApplicationMergePatchPlusJSONRequestBody = ProviderPatch
+
 // ApplyProviderJSONRequestBody defines body for ApplyProvider for application/json ContentType.
 type ApplyProviderJSONRequestBody = Provider
 
+// CheckProvidersHealthJSONRequestBody defines body for CheckProvidersHealth for application/json ContentType.
+type CheckProvidersHealthJSONRequestBody = ProviderHealthCheckRequest
+
 // Getter for additional properties for ProviderMetadata. Returns the specified
 // element and whether it was found
 func (a ProviderMetadata) Get(fieldName string) (value interface{}, found bool) {
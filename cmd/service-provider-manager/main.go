@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 	"net"
 	"os"
 	"os/signal"
@@ -12,8 +13,13 @@ import (
 	"github.com/dcm-project/service-provider-manager/internal/config"
 	"github.com/dcm-project/service-provider-manager/internal/handlers"
 	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
+	"github.com/dcm-project/service-provider-manager/internal/logging"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	"github.com/dcm-project/service-provider-manager/internal/reconciler"
 	"github.com/dcm-project/service-provider-manager/internal/service"
 	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
 )
 
 func main() {
@@ -22,6 +28,13 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	logLevel, err := logging.ParseLevel(cfg.Service.LogLevel)
+	if err != nil {
+		log.Fatalf("Invalid SVC_LOG_LEVEL: %v", err)
+	}
+	logger := logging.New(os.Stderr, logLevel)
+	slog.SetDefault(logger)
+
 	// Initialize database
 	db, err := store.InitDB(cfg)
 	if err != nil {
@@ -32,8 +45,64 @@ func main() {
 	dataStore := store.NewStore(db)
 	defer dataStore.Close()
 
-	providerService := service.NewProviderService(dataStore)
-	handler := handlers.NewHandler(providerService)
+	endpointSchemePolicy, err := service.ParseEndpointSchemePolicy(cfg.Service.EndpointSchemePolicy)
+	if err != nil {
+		log.Fatalf("Invalid SVC_ENDPOINT_SCHEME_POLICY: %v", err)
+	}
+
+	hostAllowlist := netpolicy.NewHostAllowlist(cfg.Service.ProviderHostAllowlist)
+	providerTransport, err := netpolicy.ProviderTransport(cfg.Service.ProviderCAFile, cfg.Service.ProviderTLSInsecure)
+	if err != nil {
+		log.Fatalf("Invalid SVC_PROVIDER_CA_FILE: %v", err)
+	}
+	if cfg.Service.ProviderTLSInsecure {
+		logger.Warn("Provider TLS certificate verification is disabled")
+	}
+
+	serviceMetrics := metrics.New(metrics.NewRegistry())
+	serviceMetrics.Registry.MustRegister(metrics.NewGaugeFunc("spm_providers", "Registered providers by health status.", "health_status",
+		func(ctx context.Context) (map[string]float64, error) {
+			counts := map[string]float64{}
+			for _, status := range []model.HealthStatus{model.HealthStatusReady, model.HealthStatusNotReady, model.HealthStatusMaintenance, model.HealthStatusUnknown} {
+				count, err := dataStore.Provider().Count(ctx, &store.ProviderFilter{HealthStatus: &status})
+				if err != nil {
+					return nil, err
+				}
+				counts[string(status)] = float64(count)
+			}
+			return counts, nil
+		}))
+
+	healthMonitor := healthcheck.NewMonitor(dataStore.Provider(), cfg.HealthCheck,
+		healthcheck.WithHostAllowlist(hostAllowlist),
+		healthcheck.WithTransport(providerTransport),
+		healthcheck.WithLogger(logger),
+		healthcheck.WithMetrics(serviceMetrics),
+	)
+
+	providerService := service.NewProviderService(dataStore,
+		service.WithResponseCache(cfg.Cache.Size, cfg.Cache.TTL),
+		service.WithEndpointProbeTimeout(cfg.HealthCheck.Timeout),
+		service.WithEndpointSchemePolicy(endpointSchemePolicy),
+		service.WithHostAllowlist(hostAllowlist),
+		service.WithProviderTransport(providerTransport),
+		service.WithHealthChecker(healthMonitor),
+		service.WithStrictPageTokens(cfg.Service.StrictPageTokens),
+		service.WithPageSizes(cfg.Service.DefaultPageSize, cfg.Service.MaxPageSize),
+		service.WithSchemaVersions(cfg.Service.SchemaVersions...),
+		service.WithLogger(logger),
+		service.WithMetrics(serviceMetrics),
+	)
+	healthMonitor.OnHealthUpdate(providerService.InvalidateCachedProvider)
+	adminService := service.NewAdminService(dataStore)
+	healthService := service.NewHealthService(map[string]service.HealthChecker{
+		"database": service.HealthCheckFunc(dataStore.Ping),
+		"monitor":  healthMonitor,
+	}, map[string]service.HealthChecker{
+		"database": service.HealthCheckFunc(dataStore.Ping),
+		"schema":   service.SchemaMigrated(dataStore.Schema()),
+	})
+	handler := handlers.NewHandler(providerService, adminService, healthService)
 
 	// Start server
 	listener, err := net.Listen("tcp", cfg.Service.Address)
@@ -41,18 +110,34 @@ func main() {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	srv := apiserver.New(cfg, listener, handler)
-
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	// Start health check monitor
-	healthMonitor := healthcheck.NewMonitor(dataStore.Provider(), cfg.HealthCheck)
 	healthMonitor.Start(ctx)
-	defer healthMonitor.Stop()
-	log.Printf("Health check monitor started (interval: 10s)")
+	logger.Info("Health check monitor started", "interval", cfg.HealthCheck.Interval)
+
+	// Start instance status reconciler
+	instanceReconciler := reconciler.NewReconciler(dataStore.ServiceTypeInstance(), dataStore.Provider(), cfg.Reconciler,
+		reconciler.WithTransport(providerTransport),
+		reconciler.WithHostAllowlist(hostAllowlist),
+		reconciler.WithLogger(logger),
+		reconciler.WithMetrics(serviceMetrics),
+	)
+	instanceReconciler.Start(ctx)
+	logger.Info("Instance status reconciler started", "interval", cfg.Reconciler.Interval)
+
+	// On shutdown the server drains in-flight requests first, then stops the
+	// background workers, and the deferred dataStore.Close runs last, once nothing
+	// writes to the database any more.
+	srv := apiserver.New(cfg, listener, handler,
+		apiserver.WithMetrics(serviceMetrics.Handler()),
+		apiserver.WithLogger(logger),
+		apiserver.OnShutdown(healthMonitor.Stop),
+		apiserver.OnShutdown(instanceReconciler.Stop),
+	)
 
-	log.Printf("Starting server on %s", listener.Addr().String())
+	logger.Info("Starting server", "address", listener.Addr().String())
 	if err := srv.Run(ctx); err != nil {
 		log.Fatalf("Server failed: %v", err)
 	}
@@ -50,6 +50,9 @@ type ServiceTypeInstance struct {
 	// Id Unique identifier for the Service Type Instance
 	Id *string `json:"id,omitempty"`
 
+	// InstanceName Name of the instance, unique among the instances of its provider
+	InstanceName *string `json:"instance_name,omitempty"`
+
 	// Path Resource path identifier
 	Path *string `json:"path,omitempty"`
 
@@ -70,6 +73,10 @@ type ServiceTypeInstanceList struct {
 
 	// NextPageToken Token for retrieving the next page of results
 	NextPageToken *string `json:"next_page_token,omitempty"`
+
+	// TotalSize Number of instances matching the filters across all pages, omitted when
+	// skip_total_size is set
+	TotalSize *int64 `json:"total_size,omitempty"`
 }
 
 // InstanceIdPath defines model for InstanceIdPath.
@@ -80,11 +87,23 @@ type ListInstancesParams struct {
 	// Type Filter service type
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 
+	// Status Only return instances in one of these statuses; repeat to match several
+	Status *[]string `form:"status,omitempty" json:"status,omitempty"`
+
+	// CreatedAfter Only return instances created at or after this time (RFC 3339)
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore Only return instances created before this time (RFC 3339)
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
 	// MaxPageSize Maximum number of results per page
 	MaxPageSize *int `form:"max_page_size,omitempty" json:"max_page_size,omitempty"`
 
 	// PageToken Token for pagination
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
+
+	// SkipTotalSize Do not count the matching instances, to save a query on large tables
+	SkipTotalSize *bool `form:"skip_total_size,omitempty" json:"skip_total_size,omitempty"`
 }
 
 // CreateInstanceParams defines parameters for CreateInstance.
@@ -188,6 +207,30 @@ func (siw *ServerInterfaceWrapper) ListInstances(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_before", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "max_page_size" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "max_page_size", r.URL.Query(), &params.MaxPageSize)
@@ -204,6 +247,14 @@ func (siw *ServerInterfaceWrapper) ListInstances(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "skip_total_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "skip_total_size", r.URL.Query(), &params.SkipTotalSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "skip_total_size", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListInstances(w, r, params)
 	}))
@@ -505,6 +556,15 @@ func (response CreateInstance400ApplicationProblemPlusJSONResponse) VisitCreateI
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateInstance404ApplicationProblemPlusJSONResponse Error
+
+func (response CreateInstance404ApplicationProblemPlusJSONResponse) VisitCreateInstanceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type CreateInstance409ApplicationProblemPlusJSONResponse Error
 
 func (response CreateInstance409ApplicationProblemPlusJSONResponse) VisitCreateInstanceResponse(w http.ResponseWriter) error {
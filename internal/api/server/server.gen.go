@@ -22,6 +22,27 @@ const (
 	Updated    ProviderStatus = "updated"
 )
 
+// Defines values for ProviderAuthType.
+const (
+	Bearer ProviderAuthType = "bearer"
+	Header ProviderAuthType = "header"
+)
+
+// Defines values for ListProvidersParamsHealthStatus.
+const (
+	Maintenance ListProvidersParamsHealthStatus = "maintenance"
+	NotReady    ListProvidersParamsHealthStatus = "not_ready"
+	Ready       ListProvidersParamsHealthStatus = "ready"
+	Unknown     ListProvidersParamsHealthStatus = "unknown"
+)
+
+// ComponentHealth Health of a single component
+type ComponentHealth struct {
+	// Status Component status, "ok" or "error". The reason a component is
+	// unhealthy is logged, not returned.
+	Status string `json:"status"`
+}
+
 // Error RFC 7807 compliant error response
 type Error struct {
 	// Detail Human-readable explanation specific to this occurrence
@@ -42,36 +63,130 @@ type Error struct {
 
 // Health Health status singleton resource
 type Health struct {
+	// Components Health of each component keyed by component name
+	Components *map[string]ComponentHealth `json:"components,omitempty"`
+
 	// Path Canonical path of the resource
 	Path *string `json:"path,omitempty"`
 
-	// Status Health status
+	// Status Overall health status: "ok", "degraded" when a component of /health
+	// fails, or "not_ready" when a readiness check fails
 	Status *string `json:"status,omitempty"`
 }
 
+// HealthEvent Outcome of one health check
+type HealthEvent struct {
+	// CertNotAfter When the certificate of an https endpoint expires
+	CertNotAfter *time.Time `json:"cert_not_after,omitempty"`
+
+	// Error Why the check failed
+	Error *string `json:"error,omitempty"`
+
+	// HealthStatus Health status stored after the check
+	HealthStatus string `json:"health_status"`
+
+	// HttpStatus Status code of the health endpoint's response, absent when it did not respond
+	HttpStatus *int `json:"http_status,omitempty"`
+
+	// LatencyMs Duration of the health request in milliseconds
+	LatencyMs int64 `json:"latency_ms"`
+
+	// Time When the check ran
+	Time time.Time `json:"time"`
+
+	// Warning A problem that does not fail the check, such as a certificate about to expire
+	Warning *string `json:"warning,omitempty"`
+}
+
+// HealthEventList defines model for HealthEventList.
+type HealthEventList struct {
+	Events []HealthEvent `json:"events"`
+}
+
+// MaintenanceWindow Planned maintenance period. Failed health checks during the window mark the
+// provider as maintenance instead of counting towards not_ready.
+type MaintenanceWindow struct {
+	// EndTime End of the maintenance window, after start_time
+	EndTime time.Time `json:"end_time"`
+
+	// StartTime Start of the maintenance window
+	StartTime time.Time `json:"start_time"`
+}
+
 // Provider Full provider resource representation
 type Provider struct {
+	// Auth Credentials sent with every call to the provider, including health checks.
+	// The token is never returned; omit it on update to keep the stored one.
+	Auth *ProviderAuth `json:"auth,omitempty"`
+
+	// ConsecutiveFailures Number of consecutive failed health checks, reset by a successful check
+	ConsecutiveFailures *int `json:"consecutive_failures,omitempty"`
+
+	// CreatePath Path appended to the endpoint to create instances. Empty creates them at
+	// the endpoint itself.
+	CreatePath *string `json:"create_path,omitempty"`
+
 	// CreateTime Timestamp when the provider was first registered
 	CreateTime *time.Time `json:"create_time,omitempty"`
 
+	// DebugLogging Log the requests sent to this provider and its responses in detail, with
+	// credentials redacted, regardless of the global log level
+	DebugLogging *bool `json:"debug_logging,omitempty"`
+
+	// DeletePath Path appended to the endpoint to delete an instance, where {id} is
+	// replaced by the provider's instance ID. Empty means /{id}.
+	DeletePath *string `json:"delete_path,omitempty"`
+
 	// DisplayName Human-readable display name for the provider
 	DisplayName *string `json:"display_name,omitempty"`
 
 	// Endpoint Full endpoint URL where the provider API is accessible
 	Endpoint string `json:"endpoint"`
 
-	// HealthStatus Health status of the provider
+	// GetPath Path appended to the endpoint to read an instance, where {id} is replaced
+	// by the provider's instance ID. Empty means /{id}.
+	GetPath *string `json:"get_path,omitempty"`
+
+	// HealthCheckDisabled Skip health checks for this provider, for static providers without a
+	// health endpoint. The provider is always reported ready.
+	HealthCheckDisabled *bool `json:"health_check_disabled,omitempty"`
+
+	// HealthExpectedStatuses Response status codes that count as healthy. When empty, any 2xx status
+	// does.
+	HealthExpectedStatuses *[]int `json:"health_expected_statuses,omitempty"`
+
+	// HealthPath Path appended to the endpoint for health checks. An empty path checks the
+	// endpoint itself.
+	HealthPath *string `json:"health_path"`
+
+	// HealthStatus Health status of the provider: ready, not_ready, maintenance when the
+	// provider failed a health check during its maintenance window, or unknown
+	// until the first health check after registration
 	HealthStatus *string `json:"health_status,omitempty"`
 
 	// Id Unique identifier for the Service Provider
 	Id *openapi_types.UUID `json:"id,omitempty"`
 
+	// InstanceCount Number of service type instances, only set when listing with include_counts
+	InstanceCount *int64 `json:"instance_count,omitempty"`
+
+	// Labels Key/value labels for grouping providers, e.g. by region or team. Keys and
+	// values are 1 to 63 letters, digits, '.', '_', '/' or '-'.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// MaintenanceWindow Planned maintenance period. Failed health checks during the window mark the
+	// provider as maintenance instead of counting towards not_ready.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+
 	// Metadata Additional metadata about the provider
 	Metadata *ProviderMetadata `json:"metadata,omitempty"`
 
 	// Name Unique name of the Service Provider
 	Name string `json:"name"`
 
+	// NextHealthCheckTime When the provider is next due for a health check, omitted before the first check
+	NextHealthCheckTime *time.Time `json:"next_health_check_time,omitempty"`
+
 	// Operations List of operations supported for this service type
 	Operations *[]string `json:"operations,omitempty"`
 
@@ -87,18 +202,85 @@ type Provider struct {
 	// Status Registration status
 	Status *ProviderStatus `json:"status,omitempty"`
 
+	// TimeoutSeconds Timeout for calls to this provider, overriding the global health check
+	// timeout. 0 uses the global timeout; values above 300 are clamped to 300.
+	TimeoutSeconds *int `json:"timeout_seconds,omitempty"`
+
 	// UpdateTime Timestamp when the provider was last updated
 	UpdateTime *time.Time `json:"update_time,omitempty"`
+
+	// Version Version of the provider's settings, incremented by every update. When
+	// set on an update, the update is rejected with 409 if the provider has
+	// been modified since that version.
+	Version *int `json:"version,omitempty"`
 }
 
 // ProviderStatus Registration status
 type ProviderStatus string
 
+// ProviderAuth Credentials sent with every call to the provider, including health checks.
+// The token is never returned; omit it on update to keep the stored one.
+type ProviderAuth struct {
+	// HeaderName Header carrying the token, required for the header type
+	HeaderName *string `json:"header_name,omitempty"`
+
+	// Token Token to send, required when registering the credentials
+	Token *string `json:"token,omitempty"`
+
+	// Type bearer sends "Authorization: Bearer <token>", header sends the token as
+	// the value of header_name
+	Type ProviderAuthType `json:"type"`
+}
+
+// ProviderAuthType bearer sends "Authorization: Bearer <token>", header sends the token as
+// the value of header_name
+type ProviderAuthType string
+
+// ProviderDeletePreview What deleting a provider would do, returned by a dry run
+type ProviderDeletePreview struct {
+	// InstanceCount Service type instances of the provider, which are kept when it is
+	// deleted with force
+	InstanceCount int64 `json:"instance_count"`
+
+	// Provider Full provider resource representation
+	Provider Provider `json:"provider"`
+}
+
+// ProviderHealthCheckRequest Providers to recheck
+type ProviderHealthCheckRequest struct {
+	// Ids IDs of the providers to recheck
+	Ids []openapi_types.UUID `json:"ids"`
+}
+
+// ProviderHealthCheckResult Outcome of rechecking one provider
+type ProviderHealthCheckResult struct {
+	// Error Why the provider is unhealthy or could not be checked
+	Error *string `json:"error,omitempty"`
+
+	// HealthStatus Health status stored after the check, absent if the check could not run
+	HealthStatus *string `json:"health_status,omitempty"`
+
+	// Healthy Whether the provider's health endpoint responded successfully
+	Healthy *bool `json:"healthy,omitempty"`
+
+	// Id Provider ID
+	Id openapi_types.UUID `json:"id"`
+}
+
+// ProviderHealthCheckResults Health check results, in request order
+type ProviderHealthCheckResults struct {
+	Results []ProviderHealthCheckResult `json:"results"`
+}
+
 // ProviderList Paginated list of providers
 type ProviderList struct {
 	// NextPageToken Token for retrieving the next page of results
 	NextPageToken *string     `json:"next_page_token,omitempty"`
 	Providers     *[]Provider `json:"providers,omitempty"`
+
+	// TotalSize Number of providers matching the filters across all pages, omitted when
+	// skip_total_size is set
+	TotalSize *int64 `json:"total_size,omitempty"`
 }
 
 // ProviderMetadata Additional metadata about the provider
@@ -117,6 +299,63 @@ type ProviderMetadata struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// ProviderPatch Provider fields to change in a partial update. Omitted fields are left
+// unchanged. The fields are validated like on a full update. Use a full update
+// to remove the maintenance window or the credentials.
+type ProviderPatch struct {
+	// Auth Credentials sent with every call to the provider, including health checks.
+	// The token is never returned; omit it on update to keep the stored one.
+	Auth *ProviderAuth `json:"auth,omitempty"`
+
+	// CreatePath Path appended to the endpoint to create instances
+	CreatePath *string `json:"create_path,omitempty"`
+
+	// DebugLogging Log the requests sent to this provider and its responses in detail
+	DebugLogging *bool `json:"debug_logging,omitempty"`
+
+	// DeletePath Path appended to the endpoint to delete an instance, containing {id}
+	DeletePath *string `json:"delete_path,omitempty"`
+
+	// Endpoint Base URL of the provider API
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// GetPath Path appended to the endpoint to read an instance, containing {id}
+	GetPath *string `json:"get_path,omitempty"`
+
+	// HealthCheckDisabled Skip health checks for this provider
+	HealthCheckDisabled *bool `json:"health_check_disabled,omitempty"`
+
+	// HealthExpectedStatuses Response status codes that count as healthy. An empty list accepts any
+	// 2xx status.
+	HealthExpectedStatuses *[]int `json:"health_expected_statuses,omitempty"`
+
+	// HealthPath Path appended to the endpoint for health checks. An empty path checks the
+	// endpoint itself.
+	HealthPath *string `json:"health_path,omitempty"`
+
+	// Labels Labels replacing the provider's labels. An empty object removes them.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// MaintenanceWindow Planned maintenance period. Failed health checks during the window mark the
+	// provider as maintenance instead of counting towards not_ready.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+
+	// Name Unique name of the provider
+	Name *string `json:"name,omitempty"`
+
+	// SchemaVersion Version of the service type schema
+	SchemaVersion *string `json:"schema_version,omitempty"`
+
+	// ServiceType Type of service offered by the provider
+	ServiceType *string `json:"service_type,omitempty"`
+
+	// TimeoutSeconds Timeout for calls to this provider; 0 uses the global timeout
+	TimeoutSeconds *int `json:"timeout_seconds,omitempty"`
+
+	// Version Version the provider must still have for the patch to apply
+	Version *int `json:"version,omitempty"`
+}
+
 // ResourceCapacity Resource capacity information
 type ResourceCapacity struct {
 	// TotalCpu Total CPU cores available
@@ -132,30 +371,131 @@ type ResourceCapacity struct {
 	TotalStorage *string `json:"total_storage,omitempty"`
 }
 
+// SchemaStatus Comparison between the database schema and the service models
+type SchemaStatus struct {
+	// ExtraColumns Columns present in the database but not mapped by any model, as table.column
+	ExtraColumns *[]string `json:"extra_columns,omitempty"`
+
+	// InSync True when no tables or columns are missing or extra
+	InSync bool `json:"in_sync"`
+
+	// MissingColumns Columns required by the models that do not exist, as table.column
+	MissingColumns *[]string `json:"missing_columns,omitempty"`
+
+	// MissingTables Tables required by the models that do not exist
+	MissingTables *[]string `json:"missing_tables,omitempty"`
+}
+
+// SchemaVersionList Schema versions supported for providers
+type SchemaVersionList struct {
+	SchemaVersions []string `json:"schema_versions"`
+}
+
+// MigrateSchemaParams defines parameters for MigrateSchema.
+type MigrateSchemaParams struct {
+	// AllowDestructive Drop columns that are no longer mapped by any model
+	AllowDestructive *bool `form:"allow_destructive,omitempty" json:"allow_destructive,omitempty"`
+}
+
 // ListProvidersParams defines parameters for ListProviders.
 type ListProvidersParams struct {
 	// Type Filter providers by service type
 	Type *string `form:"type,omitempty" json:"type,omitempty"`
 
-	// MaxPageSize Maximum number of results per page
+	// HealthStatus Filter providers by health status
+	HealthStatus *ListProvidersParamsHealthStatus `form:"health_status,omitempty" json:"health_status,omitempty"`
+
+	// Labels Only return providers having all of these labels, as comma-separated
+	// key=value pairs, e.g. "region=us-east,team=infra"
+	Labels *string `form:"labels,omitempty" json:"labels,omitempty"`
+
+	// NameContains Filter providers whose name contains this text
+	NameContains *string `form:"name_contains,omitempty" json:"name_contains,omitempty"`
+
+	// MaxPageSize Maximum number of results per page. Defaults to and is capped at the
+	// server's configured page sizes, 100 unless configured otherwise.
 	MaxPageSize *int `form:"max_page_size,omitempty" json:"max_page_size,omitempty"`
 
 	// PageToken Token for pagination
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
+
+	// SkipTotalSize Do not count the matching providers, to save a query on large tables
+	SkipTotalSize *bool `form:"skip_total_size,omitempty" json:"skip_total_size,omitempty"`
+
+	// OrderBy Sort order: name, create_time or update_time, optionally followed by
+	// asc or desc, e.g. "name desc". Defaults to create_time. A page_token
+	// is only valid with the order_by it was issued for.
+	OrderBy *string `form:"order_by,omitempty" json:"order_by,omitempty"`
+
+	// IncludeCounts Include each provider's number of service type instances
+	IncludeCounts *bool `form:"include_counts,omitempty" json:"include_counts,omitempty"`
 }
 
+// ListProvidersParamsHealthStatus defines parameters for ListProviders.
+type ListProvidersParamsHealthStatus string
+
 // CreateProviderParams defines parameters for CreateProvider.
 type CreateProviderParams struct {
 	// Id Optional provider ID for idempotent registration
 	Id *openapi_types.UUID `form:"id,omitempty" json:"id,omitempty"`
+
+	// ValidateEndpoint Probe the endpoint's /health before registering
+	ValidateEndpoint *bool `form:"validate_endpoint,omitempty" json:"validate_endpoint,omitempty"`
+}
+
+// DeleteProviderParams defines parameters for DeleteProvider.
+type DeleteProviderParams struct {
+	// Force Delete the provider even if it still has service type instances
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+
+	// DryRun Run the same checks as the delete, without deleting anything, and
+	// return what would be deleted
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// PatchProviderParams defines parameters for PatchProvider.
+type PatchProviderParams struct {
+	// IfMatch Version the provider must still have for the patch to apply, as
+	// returned in its version field. Takes precedence over the version in
+	// the body.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ApplyProviderParams defines parameters for ApplyProvider.
+type ApplyProviderParams struct {
+	// ValidateEndpoint Probe a changed endpoint's /health before applying the update
+	ValidateEndpoint *bool `form:"validate_endpoint,omitempty" json:"validate_endpoint,omitempty"`
+
+	// IfMatch Version the provider must still have for the update to apply, as
+	// returned in its version field. Takes precedence over the version in
+	// the body.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// ListProviderHealthEventsParams defines parameters for ListProviderHealthEvents.
+type ListProviderHealthEventsParams struct {
+	// Limit Maximum number of events to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetProviderByNameParams defines parameters for GetProviderByName.
+type GetProviderByNameParams struct {
+	// Name Name of the provider
+	Name string `form:"name" json:"name"`
 }
 
 // CreateProviderJSONRequestBody defines body for CreateProvider for application/json ContentType.
 type CreateProviderJSONRequestBody = Provider
 
+// PatchProviderApplicationMergePatchPlusJSONRequestBody defines body for PatchProvider for application/merge-patch+json ContentType.
+type PatchProviderApplicationMergePatchPlusJSONRequestBody = ProviderPatch
+
 // ApplyProviderJSONRequestBody defines body for ApplyProvider for application/json ContentType.
 type ApplyProviderJSONRequestBody = Provider
 
+// CheckProvidersHealthJSONRequestBody defines body for CheckProvidersHealth for application/json ContentType.
+type CheckProvidersHealthJSONRequestBody = ProviderHealthCheckRequest
+
 // Getter for additional properties for ProviderMetadata. Returns the specified
 // element and whether it was found
 func (a ProviderMetadata) Get(fieldName string) (value interface{}, found bool) {
@@ -271,9 +611,18 @@ func (a ProviderMetadata) MarshalJSON() ([]byte, error) {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Check database schema
+	// (GET /admin/schema:check)
+	CheckSchema(w http.ResponseWriter, r *http.Request)
+	// Migrate database schema
+	// (POST /admin/schema:migrate)
+	MigrateSchema(w http.ResponseWriter, r *http.Request, params MigrateSchemaParams)
 	// Health check
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Liveness check
+	// (GET /livez)
+	GetLiveness(w http.ResponseWriter, r *http.Request)
 	// List all providers
 	// (GET /providers)
 	ListProviders(w http.ResponseWriter, r *http.Request, params ListProvidersParams)
@@ -282,25 +631,67 @@ type ServerInterface interface {
 	CreateProvider(w http.ResponseWriter, r *http.Request, params CreateProviderParams)
 	// Delete a service Provider
 	// (DELETE /providers/{providerId})
-	DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params DeleteProviderParams)
 	// Get a provider
 	// (GET /providers/{providerId})
 	GetProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	// Partially update a Service Provider
+	// (PATCH /providers/{providerId})
+	PatchProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params PatchProviderParams)
 	// Update a Service Provider
 	// (PUT /providers/{providerId})
-	ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ApplyProviderParams)
+	// List a provider's recent health checks
+	// (GET /providers/{providerId}/health-events)
+	ListProviderHealthEvents(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ListProviderHealthEventsParams)
+	// Recheck the health of a provider
+	// (POST /providers/{providerId}:checkHealth)
+	CheckProviderHealth(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	// Restore a deleted provider
+	// (POST /providers/{providerId}:restore)
+	RestoreProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID)
+	// Get a service provider by name
+	// (GET /providers:byName)
+	GetProviderByName(w http.ResponseWriter, r *http.Request, params GetProviderByNameParams)
+	// Recheck the health of several providers
+	// (POST /providers:checkHealth)
+	CheckProvidersHealth(w http.ResponseWriter, r *http.Request)
+	// Readiness check
+	// (GET /readyz)
+	GetReadiness(w http.ResponseWriter, r *http.Request)
+	// List the supported schema versions
+	// (GET /schema-versions)
+	ListSchemaVersions(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// Check database schema
+// (GET /admin/schema:check)
+func (_ Unimplemented) CheckSchema(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Migrate database schema
+// (POST /admin/schema:migrate)
+func (_ Unimplemented) MigrateSchema(w http.ResponseWriter, r *http.Request, params MigrateSchemaParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Health check
 // (GET /health)
 func (_ Unimplemented) GetHealth(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Liveness check
+// (GET /livez)
+func (_ Unimplemented) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List all providers
 // (GET /providers)
 func (_ Unimplemented) ListProviders(w http.ResponseWriter, r *http.Request, params ListProvidersParams) {
@@ -315,7 +706,7 @@ func (_ Unimplemented) CreateProvider(w http.ResponseWriter, r *http.Request, pa
 
 // Delete a service Provider
 // (DELETE /providers/{providerId})
-func (_ Unimplemented) DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (_ Unimplemented) DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params DeleteProviderParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -325,9 +716,57 @@ func (_ Unimplemented) GetProvider(w http.ResponseWriter, r *http.Request, provi
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Partially update a Service Provider
+// (PATCH /providers/{providerId})
+func (_ Unimplemented) PatchProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params PatchProviderParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Update a Service Provider
 // (PUT /providers/{providerId})
-func (_ Unimplemented) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (_ Unimplemented) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ApplyProviderParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a provider's recent health checks
+// (GET /providers/{providerId}/health-events)
+func (_ Unimplemented) ListProviderHealthEvents(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ListProviderHealthEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Recheck the health of a provider
+// (POST /providers/{providerId}:checkHealth)
+func (_ Unimplemented) CheckProviderHealth(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a deleted provider
+// (POST /providers/{providerId}:restore)
+func (_ Unimplemented) RestoreProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a service provider by name
+// (GET /providers:byName)
+func (_ Unimplemented) GetProviderByName(w http.ResponseWriter, r *http.Request, params GetProviderByNameParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Recheck the health of several providers
+// (POST /providers:checkHealth)
+func (_ Unimplemented) CheckProvidersHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Readiness check
+// (GET /readyz)
+func (_ Unimplemented) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the supported schema versions
+// (GET /schema-versions)
+func (_ Unimplemented) ListSchemaVersions(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -340,6 +779,47 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// CheckSchema operation middleware
+func (siw *ServerInterfaceWrapper) CheckSchema(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckSchema(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MigrateSchema operation middleware
+func (siw *ServerInterfaceWrapper) MigrateSchema(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MigrateSchemaParams
+
+	// ------------- Optional query parameter "allow_destructive" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "allow_destructive", r.URL.Query(), &params.AllowDestructive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "allow_destructive", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MigrateSchema(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetHealth operation middleware
 func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
 
@@ -354,6 +834,20 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
+// GetLiveness operation middleware
+func (siw *ServerInterfaceWrapper) GetLiveness(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLiveness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListProviders operation middleware
 func (siw *ServerInterfaceWrapper) ListProviders(w http.ResponseWriter, r *http.Request) {
 
@@ -370,6 +864,30 @@ func (siw *ServerInterfaceWrapper) ListProviders(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "health_status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "health_status", r.URL.Query(), &params.HealthStatus)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "health_status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labels" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labels", r.URL.Query(), &params.Labels)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labels", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name_contains" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name_contains", r.URL.Query(), &params.NameContains)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name_contains", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "max_page_size" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "max_page_size", r.URL.Query(), &params.MaxPageSize)
@@ -386,6 +904,30 @@ func (siw *ServerInterfaceWrapper) ListProviders(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// ------------- Optional query parameter "skip_total_size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "skip_total_size", r.URL.Query(), &params.SkipTotalSize)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "skip_total_size", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order_by", r.URL.Query(), &params.OrderBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_counts" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_counts", r.URL.Query(), &params.IncludeCounts)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_counts", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListProviders(w, r, params)
 	}))
@@ -413,6 +955,14 @@ func (siw *ServerInterfaceWrapper) CreateProvider(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "validate_endpoint" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "validate_endpoint", r.URL.Query(), &params.ValidateEndpoint)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validate_endpoint", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.CreateProvider(w, r, params)
 	}))
@@ -438,8 +988,27 @@ func (siw *ServerInterfaceWrapper) DeleteProvider(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteProviderParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteProvider(w, r, providerId)
+		siw.Handler.DeleteProvider(w, r, providerId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -474,8 +1043,8 @@ func (siw *ServerInterfaceWrapper) GetProvider(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// ApplyProvider operation middleware
-func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.Request) {
+// PatchProvider operation middleware
+func (siw *ServerInterfaceWrapper) PatchProvider(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -488,8 +1057,32 @@ func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchProviderParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ApplyProvider(w, r, providerId)
+		siw.Handler.PatchProvider(w, r, providerId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -499,21 +1092,240 @@ func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// ApplyProvider operation middleware
+func (siw *ServerInterfaceWrapper) ApplyProvider(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
 
-type UnmarshalingParamError struct {
-	ParamName string
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ApplyProviderParams
+
+	// ------------- Optional query parameter "validate_endpoint" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "validate_endpoint", r.URL.Query(), &params.ValidateEndpoint)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validate_endpoint", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApplyProvider(w, r, providerId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListProviderHealthEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListProviderHealthEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListProviderHealthEventsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProviderHealthEvents(w, r, providerId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckProviderHealth operation middleware
+func (siw *ServerInterfaceWrapper) CheckProviderHealth(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckProviderHealth(w, r, providerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreProvider operation middleware
+func (siw *ServerInterfaceWrapper) RestoreProvider(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "providerId" -------------
+	var providerId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "providerId", chi.URLParam(r, "providerId"), &providerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "providerId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreProvider(w, r, providerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProviderByName operation middleware
+func (siw *ServerInterfaceWrapper) GetProviderByName(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetProviderByNameParams
+
+	// ------------- Required query parameter "name" -------------
+
+	if paramValue := r.URL.Query().Get("name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProviderByName(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CheckProvidersHealth operation middleware
+func (siw *ServerInterfaceWrapper) CheckProvidersHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckProvidersHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadiness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSchemaVersions operation middleware
+func (siw *ServerInterfaceWrapper) ListSchemaVersions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSchemaVersions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
 	Err       error
 }
 
@@ -612,9 +1424,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/schema:check", wrapper.CheckSchema)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/schema:migrate", wrapper.MigrateSchema)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/health", wrapper.GetHealth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/livez", wrapper.GetLiveness)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/providers", wrapper.ListProviders)
 	})
@@ -627,141 +1448,284 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/providers/{providerId}", wrapper.GetProvider)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/providers/{providerId}", wrapper.PatchProvider)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/providers/{providerId}", wrapper.ApplyProvider)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/providers/{providerId}/health-events", wrapper.ListProviderHealthEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/providers/{providerId}:checkHealth", wrapper.CheckProviderHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/providers/{providerId}:restore", wrapper.RestoreProvider)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/providers:byName", wrapper.GetProviderByName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/providers:checkHealth", wrapper.CheckProvidersHealth)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/readyz", wrapper.GetReadiness)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/schema-versions", wrapper.ListSchemaVersions)
+	})
 
 	return r
 }
 
-type GetHealthRequestObject struct {
+type CheckSchemaRequestObject struct {
 }
 
-type GetHealthResponseObject interface {
-	VisitGetHealthResponse(w http.ResponseWriter) error
+type CheckSchemaResponseObject interface {
+	VisitCheckSchemaResponse(w http.ResponseWriter) error
 }
 
-type GetHealth200JSONResponse Health
+type CheckSchema200JSONResponse SchemaStatus
 
-func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+func (response CheckSchema200JSONResponse) VisitCheckSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListProvidersRequestObject struct {
-	Params ListProvidersParams
-}
-
-type ListProvidersResponseObject interface {
-	VisitListProvidersResponse(w http.ResponseWriter) error
-}
-
-type ListProviders200JSONResponse ProviderList
+type CheckSchema401ApplicationProblemPlusJSONResponse Error
 
-func (response ListProviders200JSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (response CheckSchema401ApplicationProblemPlusJSONResponse) VisitCheckSchemaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListProviders400ApplicationProblemPlusJSONResponse Error
+type CheckSchema403ApplicationProblemPlusJSONResponse Error
 
-func (response ListProviders400ApplicationProblemPlusJSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
+func (response CheckSchema403ApplicationProblemPlusJSONResponse) VisitCheckSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListProvidersdefaultApplicationProblemPlusJSONResponse struct {
+type CheckSchemadefaultApplicationProblemPlusJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response ListProvidersdefaultApplicationProblemPlusJSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
+func (response CheckSchemadefaultApplicationProblemPlusJSONResponse) VisitCheckSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateProviderRequestObject struct {
-	Params CreateProviderParams
-	Body   *CreateProviderJSONRequestBody
+type MigrateSchemaRequestObject struct {
+	Params MigrateSchemaParams
 }
 
-type CreateProviderResponseObject interface {
-	VisitCreateProviderResponse(w http.ResponseWriter) error
+type MigrateSchemaResponseObject interface {
+	VisitMigrateSchemaResponse(w http.ResponseWriter) error
 }
 
-type CreateProvider200JSONResponse Provider
+type MigrateSchema200JSONResponse SchemaStatus
 
-func (response CreateProvider200JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+func (response MigrateSchema200JSONResponse) VisitMigrateSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateProvider201JSONResponse Provider
-
-func (response CreateProvider201JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateProvider400ApplicationProblemPlusJSONResponse Error
-
-func (response CreateProvider400ApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type CreateProvider409ApplicationProblemPlusJSONResponse Error
+type MigrateSchema401ApplicationProblemPlusJSONResponse Error
 
-func (response CreateProvider409ApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+func (response MigrateSchema401ApplicationProblemPlusJSONResponse) VisitMigrateSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(409)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateProvider422ApplicationProblemPlusJSONResponse Error
+type MigrateSchema403ApplicationProblemPlusJSONResponse Error
 
-func (response CreateProvider422ApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+func (response MigrateSchema403ApplicationProblemPlusJSONResponse) VisitMigrateSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(422)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateProviderdefaultApplicationProblemPlusJSONResponse struct {
+type MigrateSchemadefaultApplicationProblemPlusJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response CreateProviderdefaultApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+func (response MigrateSchemadefaultApplicationProblemPlusJSONResponse) VisitMigrateSchemaResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type DeleteProviderRequestObject struct {
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse Health
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLivenessRequestObject struct {
+}
+
+type GetLivenessResponseObject interface {
+	VisitGetLivenessResponse(w http.ResponseWriter) error
+}
+
+type GetLiveness200JSONResponse Health
+
+func (response GetLiveness200JSONResponse) VisitGetLivenessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProvidersRequestObject struct {
+	Params ListProvidersParams
+}
+
+type ListProvidersResponseObject interface {
+	VisitListProvidersResponse(w http.ResponseWriter) error
+}
+
+type ListProviders200JSONResponse ProviderList
+
+func (response ListProviders200JSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProviders400ApplicationProblemPlusJSONResponse Error
+
+func (response ListProviders400ApplicationProblemPlusJSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProvidersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ListProvidersdefaultApplicationProblemPlusJSONResponse) VisitListProvidersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateProviderRequestObject struct {
+	Params CreateProviderParams
+	Body   *CreateProviderJSONRequestBody
+}
+
+type CreateProviderResponseObject interface {
+	VisitCreateProviderResponse(w http.ResponseWriter) error
+}
+
+type CreateProvider200JSONResponse Provider
+
+func (response CreateProvider200JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvider201JSONResponse Provider
+
+func (response CreateProvider201JSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvider400ApplicationProblemPlusJSONResponse Error
+
+func (response CreateProvider400ApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvider409ApplicationProblemPlusJSONResponse Error
+
+func (response CreateProvider409ApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvider422ApplicationProblemPlusJSONResponse Error
+
+func (response CreateProvider422ApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProviderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateProviderdefaultApplicationProblemPlusJSONResponse) VisitCreateProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteProviderRequestObject struct {
 	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     DeleteProviderParams
 }
 
 type DeleteProviderResponseObject interface {
 	VisitDeleteProviderResponse(w http.ResponseWriter) error
 }
 
+type DeleteProvider200JSONResponse ProviderDeletePreview
+
+func (response DeleteProvider200JSONResponse) VisitDeleteProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteProvider204Response struct {
 }
 
@@ -788,6 +1752,15 @@ func (response DeleteProvider404ApplicationProblemPlusJSONResponse) VisitDeleteP
 	return json.NewEncoder(w).Encode(response)
 }
 
+type DeleteProvider409ApplicationProblemPlusJSONResponse Error
+
+func (response DeleteProvider409ApplicationProblemPlusJSONResponse) VisitDeleteProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteProviderdefaultApplicationProblemPlusJSONResponse struct {
 	Body       Error
 	StatusCode int
@@ -808,13 +1781,21 @@ type GetProviderResponseObject interface {
 	VisitGetProviderResponse(w http.ResponseWriter) error
 }
 
-type GetProvider200JSONResponse Provider
+type GetProvider200ResponseHeaders struct {
+	ETag string
+}
+
+type GetProvider200JSONResponse struct {
+	Body    Provider
+	Headers GetProvider200ResponseHeaders
+}
 
 func (response GetProvider200JSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type GetProvider400ApplicationProblemPlusJSONResponse Error
@@ -830,85 +1811,438 @@ type GetProvider404ApplicationProblemPlusJSONResponse Error
 
 func (response GetProvider404ApplicationProblemPlusJSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(404)
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProviderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetProviderdefaultApplicationProblemPlusJSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchProviderRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     PatchProviderParams
+	Body       *PatchProviderApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchProviderResponseObject interface {
+	VisitPatchProviderResponse(w http.ResponseWriter) error
+}
+
+type PatchProvider200JSONResponse Provider
+
+func (response PatchProvider200JSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider400ApplicationProblemPlusJSONResponse Error
+
+func (response PatchProvider400ApplicationProblemPlusJSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider404ApplicationProblemPlusJSONResponse Error
+
+func (response PatchProvider404ApplicationProblemPlusJSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProvider409ApplicationProblemPlusJSONResponse Error
+
+func (response PatchProvider409ApplicationProblemPlusJSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchProviderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PatchProviderdefaultApplicationProblemPlusJSONResponse) VisitPatchProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ApplyProviderRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     ApplyProviderParams
+	Body       *ApplyProviderJSONRequestBody
+}
+
+type ApplyProviderResponseObject interface {
+	VisitApplyProviderResponse(w http.ResponseWriter) error
+}
+
+type ApplyProvider200JSONResponse Provider
+
+func (response ApplyProvider200JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider400ApplicationProblemPlusJSONResponse Error
+
+func (response ApplyProvider400ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider404ApplicationProblemPlusJSONResponse Error
+
+func (response ApplyProvider404ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider409ApplicationProblemPlusJSONResponse Error
+
+func (response ApplyProvider409ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProvider422ApplicationProblemPlusJSONResponse Error
+
+func (response ApplyProvider422ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApplyProviderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ApplyProviderdefaultApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListProviderHealthEventsRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+	Params     ListProviderHealthEventsParams
+}
+
+type ListProviderHealthEventsResponseObject interface {
+	VisitListProviderHealthEventsResponse(w http.ResponseWriter) error
+}
+
+type ListProviderHealthEvents200JSONResponse HealthEventList
+
+func (response ListProviderHealthEvents200JSONResponse) VisitListProviderHealthEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProviderHealthEvents400ApplicationProblemPlusJSONResponse Error
+
+func (response ListProviderHealthEvents400ApplicationProblemPlusJSONResponse) VisitListProviderHealthEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProviderHealthEvents404ApplicationProblemPlusJSONResponse Error
+
+func (response ListProviderHealthEvents404ApplicationProblemPlusJSONResponse) VisitListProviderHealthEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProviderHealthEventsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ListProviderHealthEventsdefaultApplicationProblemPlusJSONResponse) VisitListProviderHealthEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CheckProviderHealthRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+}
+
+type CheckProviderHealthResponseObject interface {
+	VisitCheckProviderHealthResponse(w http.ResponseWriter) error
+}
+
+type CheckProviderHealth200JSONResponse ProviderHealthCheckResult
+
+func (response CheckProviderHealth200JSONResponse) VisitCheckProviderHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckProviderHealth404ApplicationProblemPlusJSONResponse Error
+
+func (response CheckProviderHealth404ApplicationProblemPlusJSONResponse) VisitCheckProviderHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckProviderHealthdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CheckProviderHealthdefaultApplicationProblemPlusJSONResponse) VisitCheckProviderHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RestoreProviderRequestObject struct {
+	ProviderId openapi_types.UUID `json:"providerId"`
+}
+
+type RestoreProviderResponseObject interface {
+	VisitRestoreProviderResponse(w http.ResponseWriter) error
+}
+
+type RestoreProvider200JSONResponse Provider
+
+func (response RestoreProvider200JSONResponse) VisitRestoreProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreProvider400ApplicationProblemPlusJSONResponse Error
+
+func (response RestoreProvider400ApplicationProblemPlusJSONResponse) VisitRestoreProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreProvider404ApplicationProblemPlusJSONResponse Error
+
+func (response RestoreProvider404ApplicationProblemPlusJSONResponse) VisitRestoreProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreProvider409ApplicationProblemPlusJSONResponse Error
+
+func (response RestoreProvider409ApplicationProblemPlusJSONResponse) VisitRestoreProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreProviderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RestoreProviderdefaultApplicationProblemPlusJSONResponse) VisitRestoreProviderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetProviderByNameRequestObject struct {
+	Params GetProviderByNameParams
+}
+
+type GetProviderByNameResponseObject interface {
+	VisitGetProviderByNameResponse(w http.ResponseWriter) error
+}
+
+type GetProviderByName200ResponseHeaders struct {
+	ETag string
+}
+
+type GetProviderByName200JSONResponse struct {
+	Body    Provider
+	Headers GetProviderByName200ResponseHeaders
+}
+
+func (response GetProviderByName200JSONResponse) VisitGetProviderByNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetProviderByName400ApplicationProblemPlusJSONResponse Error
+
+func (response GetProviderByName400ApplicationProblemPlusJSONResponse) VisitGetProviderByNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProviderByName404ApplicationProblemPlusJSONResponse Error
+
+func (response GetProviderByName404ApplicationProblemPlusJSONResponse) VisitGetProviderByNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProviderByNamedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetProviderByNamedefaultApplicationProblemPlusJSONResponse) VisitGetProviderByNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CheckProvidersHealthRequestObject struct {
+	Body *CheckProvidersHealthJSONRequestBody
+}
+
+type CheckProvidersHealthResponseObject interface {
+	VisitCheckProvidersHealthResponse(w http.ResponseWriter) error
+}
+
+type CheckProvidersHealth200JSONResponse ProviderHealthCheckResults
+
+func (response CheckProvidersHealth200JSONResponse) VisitCheckProvidersHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CheckProvidersHealth400ApplicationProblemPlusJSONResponse Error
+
+func (response CheckProvidersHealth400ApplicationProblemPlusJSONResponse) VisitCheckProvidersHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetProviderdefaultApplicationProblemPlusJSONResponse struct {
+type CheckProvidersHealthdefaultApplicationProblemPlusJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response GetProviderdefaultApplicationProblemPlusJSONResponse) VisitGetProviderResponse(w http.ResponseWriter) error {
+func (response CheckProvidersHealthdefaultApplicationProblemPlusJSONResponse) VisitCheckProvidersHealthResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ApplyProviderRequestObject struct {
-	ProviderId openapi_types.UUID `json:"providerId"`
-	Body       *ApplyProviderJSONRequestBody
+type GetReadinessRequestObject struct {
 }
 
-type ApplyProviderResponseObject interface {
-	VisitApplyProviderResponse(w http.ResponseWriter) error
+type GetReadinessResponseObject interface {
+	VisitGetReadinessResponse(w http.ResponseWriter) error
 }
 
-type ApplyProvider200JSONResponse Provider
+type GetReadiness200JSONResponse Health
 
-func (response ApplyProvider200JSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
+func (response GetReadiness200JSONResponse) VisitGetReadinessResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApplyProvider400ApplicationProblemPlusJSONResponse Error
+type GetReadiness503JSONResponse Health
 
-func (response ApplyProvider400ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(400)
+func (response GetReadiness503JSONResponse) VisitGetReadinessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApplyProvider404ApplicationProblemPlusJSONResponse Error
-
-func (response ApplyProvider404ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
+type ListSchemaVersionsRequestObject struct {
 }
 
-type ApplyProvider409ApplicationProblemPlusJSONResponse Error
-
-func (response ApplyProvider409ApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(409)
-
-	return json.NewEncoder(w).Encode(response)
+type ListSchemaVersionsResponseObject interface {
+	VisitListSchemaVersionsResponse(w http.ResponseWriter) error
 }
 
-type ApplyProviderdefaultApplicationProblemPlusJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+type ListSchemaVersions200JSONResponse SchemaVersionList
 
-func (response ApplyProviderdefaultApplicationProblemPlusJSONResponse) VisitApplyProviderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(response.StatusCode)
+func (response ListSchemaVersions200JSONResponse) VisitListSchemaVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response.Body)
+	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Check database schema
+	// (GET /admin/schema:check)
+	CheckSchema(ctx context.Context, request CheckSchemaRequestObject) (CheckSchemaResponseObject, error)
+	// Migrate database schema
+	// (POST /admin/schema:migrate)
+	MigrateSchema(ctx context.Context, request MigrateSchemaRequestObject) (MigrateSchemaResponseObject, error)
 	// Health check
 	// (GET /health)
 	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check
+	// (GET /livez)
+	GetLiveness(ctx context.Context, request GetLivenessRequestObject) (GetLivenessResponseObject, error)
 	// List all providers
 	// (GET /providers)
 	ListProviders(ctx context.Context, request ListProvidersRequestObject) (ListProvidersResponseObject, error)
@@ -921,9 +2255,33 @@ type StrictServerInterface interface {
 	// Get a provider
 	// (GET /providers/{providerId})
 	GetProvider(ctx context.Context, request GetProviderRequestObject) (GetProviderResponseObject, error)
+	// Partially update a Service Provider
+	// (PATCH /providers/{providerId})
+	PatchProvider(ctx context.Context, request PatchProviderRequestObject) (PatchProviderResponseObject, error)
 	// Update a Service Provider
 	// (PUT /providers/{providerId})
 	ApplyProvider(ctx context.Context, request ApplyProviderRequestObject) (ApplyProviderResponseObject, error)
+	// List a provider's recent health checks
+	// (GET /providers/{providerId}/health-events)
+	ListProviderHealthEvents(ctx context.Context, request ListProviderHealthEventsRequestObject) (ListProviderHealthEventsResponseObject, error)
+	// Recheck the health of a provider
+	// (POST /providers/{providerId}:checkHealth)
+	CheckProviderHealth(ctx context.Context, request CheckProviderHealthRequestObject) (CheckProviderHealthResponseObject, error)
+	// Restore a deleted provider
+	// (POST /providers/{providerId}:restore)
+	RestoreProvider(ctx context.Context, request RestoreProviderRequestObject) (RestoreProviderResponseObject, error)
+	// Get a service provider by name
+	// (GET /providers:byName)
+	GetProviderByName(ctx context.Context, request GetProviderByNameRequestObject) (GetProviderByNameResponseObject, error)
+	// Recheck the health of several providers
+	// (POST /providers:checkHealth)
+	CheckProvidersHealth(ctx context.Context, request CheckProvidersHealthRequestObject) (CheckProvidersHealthResponseObject, error)
+	// Readiness check
+	// (GET /readyz)
+	GetReadiness(ctx context.Context, request GetReadinessRequestObject) (GetReadinessResponseObject, error)
+	// List the supported schema versions
+	// (GET /schema-versions)
+	ListSchemaVersions(ctx context.Context, request ListSchemaVersionsRequestObject) (ListSchemaVersionsResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -955,6 +2313,56 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// CheckSchema operation middleware
+func (sh *strictHandler) CheckSchema(w http.ResponseWriter, r *http.Request) {
+	var request CheckSchemaRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CheckSchema(ctx, request.(CheckSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CheckSchema")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CheckSchemaResponseObject); ok {
+		if err := validResponse.VisitCheckSchemaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MigrateSchema operation middleware
+func (sh *strictHandler) MigrateSchema(w http.ResponseWriter, r *http.Request, params MigrateSchemaParams) {
+	var request MigrateSchemaRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MigrateSchema(ctx, request.(MigrateSchemaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MigrateSchema")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MigrateSchemaResponseObject); ok {
+		if err := validResponse.VisitMigrateSchemaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetHealth operation middleware
 func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
 	var request GetHealthRequestObject
@@ -979,6 +2387,30 @@ func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetLiveness operation middleware
+func (sh *strictHandler) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	var request GetLivenessRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLiveness(ctx, request.(GetLivenessRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLiveness")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLivenessResponseObject); ok {
+		if err := validResponse.VisitGetLivenessResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListProviders operation middleware
 func (sh *strictHandler) ListProviders(w http.ResponseWriter, r *http.Request, params ListProvidersParams) {
 	var request ListProvidersRequestObject
@@ -1039,10 +2471,11 @@ func (sh *strictHandler) CreateProvider(w http.ResponseWriter, r *http.Request,
 }
 
 // DeleteProvider operation middleware
-func (sh *strictHandler) DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (sh *strictHandler) DeleteProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params DeleteProviderParams) {
 	var request DeleteProviderRequestObject
 
 	request.ProviderId = providerId
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteProvider(ctx, request.(DeleteProviderRequestObject))
@@ -1090,11 +2523,46 @@ func (sh *strictHandler) GetProvider(w http.ResponseWriter, r *http.Request, pro
 	}
 }
 
+// PatchProvider operation middleware
+func (sh *strictHandler) PatchProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params PatchProviderParams) {
+	var request PatchProviderRequestObject
+
+	request.ProviderId = providerId
+	request.Params = params
+
+	var body PatchProviderApplicationMergePatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchProvider(ctx, request.(PatchProviderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchProvider")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchProviderResponseObject); ok {
+		if err := validResponse.VisitPatchProviderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ApplyProvider operation middleware
-func (sh *strictHandler) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+func (sh *strictHandler) ApplyProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ApplyProviderParams) {
 	var request ApplyProviderRequestObject
 
 	request.ProviderId = providerId
+	request.Params = params
 
 	var body ApplyProviderJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -1122,3 +2590,187 @@ func (sh *strictHandler) ApplyProvider(w http.ResponseWriter, r *http.Request, p
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// ListProviderHealthEvents operation middleware
+func (sh *strictHandler) ListProviderHealthEvents(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID, params ListProviderHealthEventsParams) {
+	var request ListProviderHealthEventsRequestObject
+
+	request.ProviderId = providerId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListProviderHealthEvents(ctx, request.(ListProviderHealthEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListProviderHealthEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListProviderHealthEventsResponseObject); ok {
+		if err := validResponse.VisitListProviderHealthEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CheckProviderHealth operation middleware
+func (sh *strictHandler) CheckProviderHealth(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+	var request CheckProviderHealthRequestObject
+
+	request.ProviderId = providerId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CheckProviderHealth(ctx, request.(CheckProviderHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CheckProviderHealth")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CheckProviderHealthResponseObject); ok {
+		if err := validResponse.VisitCheckProviderHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RestoreProvider operation middleware
+func (sh *strictHandler) RestoreProvider(w http.ResponseWriter, r *http.Request, providerId openapi_types.UUID) {
+	var request RestoreProviderRequestObject
+
+	request.ProviderId = providerId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreProvider(ctx, request.(RestoreProviderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreProvider")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreProviderResponseObject); ok {
+		if err := validResponse.VisitRestoreProviderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetProviderByName operation middleware
+func (sh *strictHandler) GetProviderByName(w http.ResponseWriter, r *http.Request, params GetProviderByNameParams) {
+	var request GetProviderByNameRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProviderByName(ctx, request.(GetProviderByNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProviderByName")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetProviderByNameResponseObject); ok {
+		if err := validResponse.VisitGetProviderByNameResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CheckProvidersHealth operation middleware
+func (sh *strictHandler) CheckProvidersHealth(w http.ResponseWriter, r *http.Request) {
+	var request CheckProvidersHealthRequestObject
+
+	var body CheckProvidersHealthJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CheckProvidersHealth(ctx, request.(CheckProvidersHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CheckProvidersHealth")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CheckProvidersHealthResponseObject); ok {
+		if err := validResponse.VisitCheckProvidersHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetReadiness operation middleware
+func (sh *strictHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	var request GetReadinessRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetReadiness(ctx, request.(GetReadinessRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetReadiness")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReadinessResponseObject); ok {
+		if err := validResponse.VisitGetReadinessResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListSchemaVersions operation middleware
+func (sh *strictHandler) ListSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	var request ListSchemaVersionsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSchemaVersions(ctx, request.(ListSchemaVersionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSchemaVersions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListSchemaVersionsResponseObject); ok {
+		if err := validResponse.VisitListSchemaVersionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
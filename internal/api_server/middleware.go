@@ -0,0 +1,303 @@
+package apiserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// adminAuth guards every route under prefix with the configured admin token, passed
+// as a bearer token. The admin routes are disabled when no token is configured.
+func adminAuth(prefix, token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, prefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if token == "" {
+				writeProblem(w, http.StatusForbidden, "admin-disabled", "Admin API disabled", "no admin token is configured")
+				return
+			}
+
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				writeProblem(w, http.StatusUnauthorized, "unauthorized", "Unauthorized", "missing or invalid admin token")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// apiKeyAuth requires one of the keys, sent as a bearer token or in the X-API-Key
+// header, on every route under prefix except the exempt ones and the admin routes,
+// which adminAuth guards with their own token. It is a no-op without keys.
+func apiKeyAuth(prefix string, keys []string, exempt ...string) func(http.Handler) http.Handler {
+	// Keys are compared by hash so that neither their content nor their length is
+	// revealed by the comparison time.
+	var hashes [][sha256.Size]byte
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			hashes = append(hashes, sha256.Sum256([]byte(key)))
+		}
+	}
+	skip := map[string]bool{}
+	for _, path := range exempt {
+		skip[prefix+path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		if len(hashes) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := r.URL.Path
+			if !strings.HasPrefix(path, prefix) || skip[path] || strings.HasPrefix(path, prefix+"/admin") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			provided := r.Header.Get("X-API-Key")
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+				provided = bearer
+			}
+			sum := sha256.Sum256([]byte(provided))
+			valid := 0
+			for _, hash := range hashes {
+				valid |= subtle.ConstantTimeCompare(sum[:], hash[:])
+			}
+			if provided == "" || valid != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeProblem(w, http.StatusUnauthorized, "unauthorized", "Unauthorized", "missing or invalid API key")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// cors lets browsers on the allowed origins call the API, "*" allowing any origin.
+// Requests from other origins get no CORS headers, so browsers keep blocking them.
+// Preflight requests are answered here, before routing and authentication.
+func cors(origins, methods, headers []string, credentials bool) func(http.Handler) http.Handler {
+	allowed := map[string]bool{}
+	for _, origin := range origins {
+		allowed[strings.TrimSuffix(strings.TrimSpace(origin), "/")] = true
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(headers, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Origin")
+			origin := r.Header.Get("Origin")
+			if origin == "" || !(allowed["*"] || allowed[origin]) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The origin is echoed rather than "*", which browsers refuse together
+			// with credentials.
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if credentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+const (
+	mediaTypeJSON        = "application/json"
+	mediaTypeProblemJSON = "application/problem+json"
+)
+
+// negotiateErrorContentType serves error responses as application/json instead of
+// application/problem+json to clients whose Accept header allows the former but not
+// the latter. The body is left unchanged.
+func negotiateErrorContentType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept := r.Header.Get("Accept")
+		if accept == "" || accepts(accept, mediaTypeProblemJSON) || !accepts(accept, mediaTypeJSON) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&problemDowngradeWriter{ResponseWriter: w}, r)
+	})
+}
+
+// problemDowngradeWriter rewrites a problem+json Content-Type to application/json
+// before the headers are sent.
+type problemDowngradeWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *problemDowngradeWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.Header().Get("Content-Type") == mediaTypeProblemJSON {
+			w.Header().Set("Content-Type", mediaTypeJSON)
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *problemDowngradeWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *problemDowngradeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// accepts reports whether the Accept header value allows the given media type,
+// honoring wildcards and treating q=0 as a rejection.
+func accepts(accept, mediaType string) bool {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		candidate := strings.ToLower(strings.TrimSpace(fields[0]))
+		if candidate != mediaType && candidate != "*/*" && candidate != mainType+"/*" {
+			continue
+		}
+		rejected := false
+		for _, param := range fields[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") && strings.Trim(value, "0.") == "" {
+				rejected = true
+			}
+		}
+		if !rejected {
+			return true
+		}
+	}
+	return false
+}
+
+// writeProblem writes an RFC 7807 error response.
+func writeProblem(w http.ResponseWriter, status int, errType, title, detail string) {
+	w.Header().Set("Content-Type", mediaTypeProblemJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(server.Error{
+		Type:   errType,
+		Title:  title,
+		Detail: &detail,
+		Status: &status,
+	})
+}
+
+// redactedBody replaces the value of sensitive JSON fields in logged bodies.
+const redactedBody = "REDACTED"
+
+// logBodies logs the request and response bodies of every call once it has been
+// served, for debugging provider integrations. The bodies are captured as the
+// handler reads and writes them, up to maxBytes each, so nothing is consumed. The
+// values of JSON fields named in redact are replaced at any depth, case
+// insensitively. Bodies that are not valid JSON, including truncated ones, are not
+// logged since they cannot be redacted; only their size is.
+func logBodies(logger *slog.Logger, redact []string, maxBytes int) func(http.Handler) http.Handler {
+	fields := make(map[string]bool, len(redact))
+	for _, name := range redact {
+		fields[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request := &cappedBuffer{max: maxBytes}
+			if r.Body != nil {
+				r.Body = readCloser{Reader: io.TeeReader(r.Body, request), Closer: r.Body}
+			}
+			response := &cappedBuffer{max: maxBytes}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(response)
+
+			next.ServeHTTP(ww, r)
+
+			logger.InfoContext(r.Context(), "HTTP bodies", "method", r.Method, "path", r.URL.Path, "status", ww.Status(),
+				"request_body", request.redacted(fields), "response_body", response.redacted(fields))
+		})
+	}
+}
+
+// cappedBuffer keeps the first max bytes written to it and counts the rest.
+type cappedBuffer struct {
+	buf   bytes.Buffer
+	max   int
+	total int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.total += len(p)
+	if room := b.max - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
+
+// redacted returns the captured body with the sensitive fields replaced, or a
+// placeholder when it cannot be redacted.
+func (b *cappedBuffer) redacted(fields map[string]bool) string {
+	if b.total == 0 {
+		return ""
+	}
+	var body any
+	if b.total > b.buf.Len() || json.Unmarshal(b.buf.Bytes(), &body) != nil {
+		return fmt.Sprintf("<%d bytes not logged>", b.total)
+	}
+	out, err := json.Marshal(redactFields(body, fields))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes not logged>", b.total)
+	}
+	return string(out)
+}
+
+// redactFields replaces the values of the named fields in decoded JSON.
+func redactFields(v any, fields map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, value := range v {
+			if fields[strings.ToLower(k)] {
+				v[k] = redactedBody
+			} else {
+				v[k] = redactFields(value, fields)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactFields(value, fields)
+		}
+	}
+	return v
+}
+
+// readCloser reads from Reader and closes Closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
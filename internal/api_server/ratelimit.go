@@ -0,0 +1,129 @@
+package apiserver
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitCleanupInterval is how often clients that have been idle long enough for
+// their bucket to refill are forgotten.
+const rateLimitCleanupInterval = time.Minute
+
+// rateLimiter keeps a token bucket per client: each request takes a token, and
+// tokens are added at rate per second up to burst.
+type rateLimiter struct {
+	rate    float64
+	burst   float64
+	clients sync.Map // client key -> *bucket
+}
+
+type bucket struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{rate: rate, burst: float64(max(burst, 1))}
+}
+
+// allow takes a token from the client's bucket. When it is empty, allow returns how
+// long until the next token is available.
+func (l *rateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	v, _ := l.clients.LoadOrStore(client, &bucket{tokens: l.burst, last: now})
+	b := v.(*bucket)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// cleanup forgets the clients whose bucket has refilled completely, which behave
+// the same as clients that were never seen.
+func (l *rateLimiter) cleanup(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	l.clients.Range(func(key, v any) bool {
+		b := v.(*bucket)
+		b.mu.Lock()
+		idle := now.Sub(b.last) >= refill
+		b.mu.Unlock()
+		if idle {
+			l.clients.Delete(key)
+		}
+		return true
+	})
+}
+
+// run cleans up idle clients periodically until ctx is done.
+func (l *rateLimiter) run(ctx context.Context) {
+	ticker := time.NewTicker(rateLimitCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.cleanup(now)
+		}
+	}
+}
+
+// middleware limits the requests that change state, POST, PUT, PATCH and DELETE,
+// under prefix. Reads, including the health endpoints, are not limited. Clients are
+// told by the API key they authenticated with when byKey is set, which requires the
+// key to have been checked already, and by IP address otherwise.
+func (l *rateLimiter) middleware(prefix string, byKey bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, prefix) || !mutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ok, wait := l.allow(rateLimitClient(r, byKey), time.Now())
+			if !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				writeProblem(w, http.StatusTooManyRequests, "rate-limited", "Too many requests", "rate limit exceeded, retry later")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rateLimitClient identifies the client of r by its API key or IP address.
+func rateLimitClient(r *http.Request, byKey bool) string {
+	if byKey {
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			return "key:" + bearer
+		}
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			return "key:" + key
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
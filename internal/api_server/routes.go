@@ -0,0 +1,61 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// VerifyRoutes checks that every operation in the spec is routed under baseURL, so a
+// spec that drifted from the generated server fails at startup instead of with a 404
+// on first use.
+func VerifyRoutes(routes chi.Routes, swagger *openapi3.T, baseURL string) error {
+	routed := map[string]bool{}
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routed[method+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("walk routes: %w", err)
+	}
+
+	var missing []string
+	for path, item := range swagger.Paths.Map() {
+		for method, op := range item.Operations() {
+			if !routed[method+" "+baseURL+path] {
+				missing = append(missing, fmt.Sprintf("%s %s (%s)", method, baseURL+path, op.OperationID))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("OpenAPI operations without a route: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// verifyHandler rejects a missing handler, and a stub that embeds the server
+// interface without setting it, whose operations would panic when first called.
+func verifyHandler(name string, handler any) error {
+	v := reflect.ValueOf(handler)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return fmt.Errorf("%s API has no handler", name)
+	}
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Interface && v.Field(i).IsNil() {
+			return fmt.Errorf("%s API handler %T embeds a nil %s, so not every operation is implemented",
+				name, handler, field.Type)
+		}
+	}
+	return nil
+}
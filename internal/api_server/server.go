@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
-	"time"
 
 	"github.com/dcm-project/service-provider-manager/api/v1alpha1"
 	"github.com/dcm-project/service-provider-manager/internal/api/server"
@@ -15,27 +15,77 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-const gracefulShutdownTimeout = 5 * time.Second
-
 type Server struct {
 	cfg      *config.Config
 	listener net.Listener
 	handler  server.StrictServerInterface
+	metrics  http.Handler
+	logger   *slog.Logger
+	// onShutdown run in order once the server has drained
+	onShutdown []func()
+}
+
+// Option configures optional Server behavior.
+type Option func(*Server)
+
+// WithMetrics serves the metrics handler at /metrics, outside the API base URL.
+func WithMetrics(handler http.Handler) Option {
+	return func(s *Server) {
+		s.metrics = handler
+	}
+}
+
+// WithLogger sets the logger request and response bodies are logged to when body
+// logging is enabled.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// OnShutdown runs fn when Run returns, after the server has stopped accepting
+// requests and the in-flight ones have finished or the graceful shutdown timeout
+// expired. Background workers that requests depend on are stopped this way.
+func OnShutdown(fn func()) Option {
+	return func(s *Server) {
+		s.onShutdown = append(s.onShutdown, fn)
+	}
 }
 
-func New(cfg *config.Config, listener net.Listener, handler server.StrictServerInterface) *Server {
-	return &Server{
+func New(cfg *config.Config, listener net.Listener, handler server.StrictServerInterface, opts ...Option) *Server {
+	s := &Server{
 		cfg:      cfg,
 		listener: listener,
 		handler:  handler,
+		logger:   slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *Server) Run(ctx context.Context) error {
 	router := chi.NewRouter()
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
+	if len(s.cfg.Service.CORSAllowedOrigins) > 0 {
+		router.Use(cors(s.cfg.Service.CORSAllowedOrigins, s.cfg.Service.CORSAllowedMethods,
+			s.cfg.Service.CORSAllowedHeaders, s.cfg.Service.CORSAllowCredentials))
+	}
+	if s.cfg.Service.RequestTimeout > 0 {
+		// Cancels the request context at the deadline, so provider calls and database
+		// queries stop, and answers 504 if the handler has not responded by then.
+		router.Use(middleware.Timeout(s.cfg.Service.RequestTimeout))
+	}
+	if s.cfg.Service.LogBodies {
+		router.Use(logBodies(s.logger, s.cfg.Service.LogBodiesRedactFields, s.cfg.Service.LogBodiesMaxBytes))
+	}
+	router.Use(negotiateErrorContentType)
 
+	if err := verifyHandler("provider", s.handler); err != nil {
+		return err
+	}
 	swagger, err := v1alpha1.GetSwagger()
 	if err != nil {
 		return fmt.Errorf("load OpenAPI spec: %w", err)
@@ -43,21 +93,59 @@ func (s *Server) Run(ctx context.Context) error {
 	if len(swagger.Servers) == 0 {
 		return fmt.Errorf("OpenAPI spec missing servers configuration")
 	}
+	baseURL := swagger.Servers[0].URL
+
+	router.Use(adminAuth(baseURL+"/admin", s.cfg.Service.AdminToken))
+	router.Use(apiKeyAuth(baseURL, s.cfg.Service.APIKeys, "/health", "/livez", "/readyz"))
+	if s.cfg.Service.RateLimit > 0 {
+		limiter := newRateLimiter(s.cfg.Service.RateLimit, s.cfg.Service.RateLimitBurst)
+		go limiter.run(ctx)
+		router.Use(limiter.middleware(baseURL, len(s.cfg.Service.APIKeys) > 0))
+	}
 
-	server.HandlerFromMuxWithBaseURL(server.NewStrictHandler(s.handler, nil), router, swagger.Servers[0].URL)
+	server.HandlerFromMuxWithBaseURL(server.NewStrictHandler(s.handler, nil), router, baseURL)
+	if err := VerifyRoutes(router, swagger, baseURL); err != nil {
+		return err
+	}
+	if s.metrics != nil {
+		router.Handle("/metrics", s.metrics)
+	}
 
-	srv := http.Server{Handler: router}
+	srv := http.Server{
+		Handler:           router,
+		ReadHeaderTimeout: s.cfg.Service.ReadHeaderTimeout,
+		ReadTimeout:       s.cfg.Service.ReadTimeout,
+		WriteTimeout:      s.cfg.Service.WriteTimeout,
+		IdleTimeout:       s.cfg.Service.IdleTimeout,
+	}
 
+	drained := make(chan struct{})
 	go func() {
+		defer close(drained)
 		<-ctx.Done()
-		ctxTimeout, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
-		defer cancel()
+		shutdownCtx := context.Background()
+		if s.cfg.Service.ShutdownTimeout > 0 {
+			var cancel context.CancelFunc
+			shutdownCtx, cancel = context.WithTimeout(shutdownCtx, s.cfg.Service.ShutdownTimeout)
+			defer cancel()
+		}
 		srv.SetKeepAlivesEnabled(false)
-		_ = srv.Shutdown(ctxTimeout)
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			// Requests still running at the deadline are cut off.
+			_ = srv.Close()
+		}
+	}()
+	defer func() {
+		for _, fn := range s.onShutdown {
+			fn()
+		}
 	}()
 
 	if err := srv.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
+	// Serve returns as soon as Shutdown starts; wait for the in-flight requests so
+	// the caller does not close the database under them.
+	<-drained
 	return nil
 }
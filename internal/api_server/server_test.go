@@ -0,0 +1,600 @@
+package apiserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/api/v1alpha1"
+	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	apiserver "github.com/dcm-project/service-provider-manager/internal/api_server"
+	"github.com/dcm-project/service-provider-manager/internal/config"
+	"github.com/dcm-project/service-provider-manager/internal/handlers"
+	"github.com/dcm-project/service-provider-manager/internal/logging"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/service"
+	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		db      *gorm.DB
+		cfg     *config.Config
+		baseURL string
+		cancel  context.CancelFunc
+		done    chan error
+	)
+
+	var opts []apiserver.Option
+
+	start := func() {
+		dataStore := store.NewStore(db)
+		healthService := service.NewHealthService(map[string]service.HealthChecker{
+			"database": service.HealthCheckFunc(dataStore.Ping),
+		}, map[string]service.HealthChecker{
+			"database": service.HealthCheckFunc(dataStore.Ping),
+			"schema":   service.SchemaMigrated(dataStore.Schema()),
+		})
+		handler := handlers.NewHandler(service.NewProviderService(dataStore), service.NewAdminService(dataStore), healthService)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		baseURL = "http://" + listener.Addr().String() + "/api/v1alpha1"
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+		srv := apiserver.New(cfg, listener, handler, opts...)
+		// The goroutine gets its own channel: a server still shutting down when the
+		// next spec starts must not write to that spec's.
+		go func(done chan error) { done <- srv.Run(ctx) }(done)
+	}
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Provider{}, &model.ServiceTypeInstance{}, &model.HealthEvent{})).To(Succeed())
+
+		cfg = &config.Config{Service: &config.ServiceConfig{ShutdownTimeout: time.Second}}
+		opts = nil
+	})
+
+	AfterEach(func() {
+		// Shutdown waits for connections the client keeps open.
+		http.DefaultClient.CloseIdleConnections()
+		cancel()
+		Eventually(done, "3s").Should(Receive(BeNil()))
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	Describe("admin endpoints", func() {
+		get := func(path, token string) *http.Response {
+			req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+
+		It("are disabled when no admin token is configured", func() {
+			start()
+
+			resp := get("/admin/schema:check", "anything")
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/problem+json"))
+		})
+
+		It("reject a missing or invalid token", func() {
+			cfg.Service.AdminToken = "s3cret"
+			start()
+
+			resp := get("/admin/schema:check", "")
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			resp = get("/admin/schema:check", "wrong")
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("serve the schema report with a valid token", func() {
+			cfg.Service.AdminToken = "s3cret"
+			start()
+
+			resp := get("/admin/schema:check", "s3cret")
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			var status server.SchemaStatus
+			Expect(json.NewDecoder(resp.Body).Decode(&status)).To(Succeed())
+			Expect(status.InSync).To(BeTrue())
+		})
+
+		It("leave the other endpoints unauthenticated", func() {
+			cfg.Service.AdminToken = "s3cret"
+			start()
+
+			resp := get("/health", "")
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("API key authentication", func() {
+		get := func(path string, header http.Header) int {
+			req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			for name, values := range header {
+				req.Header[name] = values
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+
+		BeforeEach(func() {
+			cfg.Service.APIKeys = []string{"key-one", "key-two"}
+		})
+
+		It("accepts a configured key as a bearer token or in X-API-Key", func() {
+			start()
+
+			Expect(get("/providers", http.Header{"Authorization": {"Bearer key-one"}})).To(Equal(http.StatusOK))
+			Expect(get("/providers", http.Header{"X-Api-Key": {"key-two"}})).To(Equal(http.StatusOK))
+		})
+
+		It("rejects a missing or unknown key", func() {
+			start()
+
+			Expect(get("/providers", nil)).To(Equal(http.StatusUnauthorized))
+			Expect(get("/providers", http.Header{"Authorization": {"Bearer key-three"}})).To(Equal(http.StatusUnauthorized))
+			Expect(get("/providers", http.Header{"X-Api-Key": {"key-on"}})).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("leaves the health endpoints unauthenticated", func() {
+			start()
+
+			Expect(get("/health", nil)).To(Equal(http.StatusOK))
+			Expect(get("/livez", nil)).To(Equal(http.StatusOK))
+			Expect(get("/readyz", nil)).To(Equal(http.StatusOK))
+		})
+
+		It("is disabled without keys", func() {
+			cfg.Service.APIKeys = nil
+			start()
+
+			Expect(get("/providers", nil)).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("rate limiting", func() {
+		do := func(method, path string) *http.Response {
+			req, err := http.NewRequest(method, baseURL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			return resp
+		}
+
+		BeforeEach(func() {
+			cfg.Service.RateLimit = 0.01
+			cfg.Service.RateLimitBurst = 3
+		})
+
+		It("rejects the request after the burst with a Retry-After", func() {
+			start()
+
+			for range 3 {
+				Expect(do(http.MethodDelete, "/providers/"+uuid.NewString()).StatusCode).To(Equal(http.StatusNotFound))
+			}
+			resp := do(http.MethodDelete, "/providers/"+uuid.NewString())
+
+			Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+			Expect(resp.Header.Get("Retry-After")).To(Equal("100"))
+		})
+
+		It("does not limit reads or the health endpoints", func() {
+			start()
+
+			for range 5 {
+				Expect(do(http.MethodGet, "/providers").StatusCode).To(Equal(http.StatusOK))
+				Expect(do(http.MethodGet, "/health").StatusCode).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	Describe("metrics endpoint", func() {
+		metricsURL := func() string {
+			return strings.TrimSuffix(baseURL, "/api/v1alpha1") + "/metrics"
+		}
+
+		It("serves the registry at /metrics when configured", func() {
+			m := metrics.New(metrics.NewRegistry())
+			m.ProviderOperations.WithLabelValues("create", "ok").Inc()
+			opts = append(opts, apiserver.WithMetrics(m.Handler()))
+			start()
+
+			resp, err := http.Get(metricsURL())
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring(`spm_provider_operations_total{operation="create",result="ok"} 1`))
+		})
+
+		It("is not served by default", func() {
+			start()
+
+			resp, err := http.Get(metricsURL())
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("body logging", func() {
+		var logs *gbytes.Buffer
+
+		BeforeEach(func() {
+			logs = gbytes.NewBuffer()
+			opts = append(opts, apiserver.WithLogger(logging.New(logs, slog.LevelInfo)))
+			cfg.Service.LogBodiesRedactFields = []string{"token"}
+			cfg.Service.LogBodiesMaxBytes = 4096
+		})
+
+		register := func() int {
+			body := `{"name":"kubevirt","service_type":"vm","schema_version":"v1alpha1","endpoint":"https://kubevirt.example.com",` +
+				`"auth":{"type":"bearer","token":"s3cret"}}`
+			resp, err := http.Post(baseURL+"/providers", "application/json", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			return resp.StatusCode
+		}
+
+		It("logs the bodies with sensitive fields redacted", func() {
+			cfg.Service.LogBodies = true
+			start()
+
+			Expect(register()).To(Equal(http.StatusCreated))
+
+			Eventually(func() string { return string(logs.Contents()) }).Should(ContainSubstring("HTTP bodies"))
+			Expect(string(logs.Contents())).To(ContainSubstring(`\"token\":\"REDACTED\"`))
+			Expect(string(logs.Contents())).To(ContainSubstring(`\"name\":\"kubevirt\"`))
+			Expect(string(logs.Contents())).NotTo(ContainSubstring("s3cret"))
+		})
+
+		It("is off by default", func() {
+			start()
+
+			Expect(register()).To(Equal(http.StatusCreated))
+
+			Consistently(func() string { return string(logs.Contents()) }, "50ms").ShouldNot(ContainSubstring("HTTP bodies"))
+		})
+	})
+
+	Describe("request timeout", func() {
+		It("cancels a slow provider call at the deadline", func() {
+			slowProvider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			}))
+			DeferCleanup(slowProvider.Close)
+			cfg.Service.RequestTimeout = 100 * time.Millisecond
+			start()
+
+			body := `{"name":"slow","service_type":"vm","schema_version":"v1alpha1","endpoint":"https://slow.example.com"}`
+			resp, err := http.Post(baseURL+"/providers", "application/json", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			var created server.Provider
+			Expect(json.NewDecoder(resp.Body).Decode(&created)).To(Succeed())
+			resp.Body.Close()
+
+			update := strings.Replace(body, "https://slow.example.com", slowProvider.URL, 1)
+			req, err := http.NewRequest(http.MethodPut, baseURL+"/providers/"+created.Id.String()+"?validate_endpoint=true", strings.NewReader(update))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", "application/json")
+			started := time.Now()
+			resp, err = http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(time.Since(started)).To(BeNumerically("<", 2*time.Second))
+			Expect(resp.StatusCode).To(BeElementOf(http.StatusUnprocessableEntity, http.StatusGatewayTimeout))
+		})
+	})
+
+	Describe("CORS", func() {
+		request := func(method, origin string, header http.Header) *http.Response {
+			req, err := http.NewRequest(method, baseURL+"/providers", nil)
+			Expect(err).NotTo(HaveOccurred())
+			for name, values := range header {
+				req.Header[name] = values
+			}
+			req.Header.Set("Origin", origin)
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			return resp
+		}
+
+		BeforeEach(func() {
+			cfg.Service.CORSAllowedOrigins = []string{"https://console.example.com"}
+			cfg.Service.CORSAllowedMethods = []string{"GET", "POST"}
+			cfg.Service.CORSAllowedHeaders = []string{"Content-Type"}
+		})
+
+		It("allows requests from an allowed origin only", func() {
+			start()
+
+			resp := request(http.MethodGet, "https://console.example.com", nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Header.Get("Access-Control-Allow-Origin")).To(Equal("https://console.example.com"))
+
+			resp = request(http.MethodGet, "https://evil.example.com", nil)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Header.Values("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+
+		It("answers preflight requests", func() {
+			start()
+
+			resp := request(http.MethodOptions, "https://console.example.com", http.Header{"Access-Control-Request-Method": {"POST"}})
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+			Expect(resp.Header.Get("Access-Control-Allow-Origin")).To(Equal("https://console.example.com"))
+			Expect(resp.Header.Get("Access-Control-Allow-Methods")).To(Equal("GET, POST"))
+			Expect(resp.Header.Get("Access-Control-Allow-Headers")).To(Equal("Content-Type"))
+			Expect(resp.Header.Get("Access-Control-Allow-Credentials")).To(BeEmpty())
+		})
+
+		It("is off when no origin is allowed", func() {
+			cfg.Service.CORSAllowedOrigins = nil
+			start()
+
+			resp := request(http.MethodGet, "https://console.example.com", nil)
+
+			Expect(resp.Header.Values("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+	})
+
+	Describe("shutdown", func() {
+		It("drains in-flight requests before running the shutdown hooks", func() {
+			entered := make(chan struct{})
+			release := make(chan struct{})
+			slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(entered)
+				<-release
+				w.WriteHeader(http.StatusOK)
+			})
+			var stopped atomic.Bool
+			opts = append(opts, apiserver.WithMetrics(slow), apiserver.OnShutdown(func() { stopped.Store(true) }))
+			start()
+
+			responses := make(chan int, 1)
+			go func() {
+				defer GinkgoRecover()
+				resp, err := http.Get(strings.TrimSuffix(baseURL, "/api/v1alpha1") + "/metrics")
+				Expect(err).NotTo(HaveOccurred())
+				resp.Body.Close()
+				responses <- resp.StatusCode
+			}()
+			Eventually(entered).Should(BeClosed())
+
+			cancel()
+			Consistently(stopped.Load, "100ms").Should(BeFalse())
+			Expect(done).NotTo(Receive())
+
+			close(release)
+			Eventually(responses).Should(Receive(Equal(http.StatusOK)))
+			Eventually(stopped.Load).Should(BeTrue())
+		})
+	})
+
+	Describe("HTTP server timeouts", func() {
+		It("closes a connection whose headers are not sent in time", func() {
+			cfg.Service.ReadHeaderTimeout = 100 * time.Millisecond
+			start()
+
+			conn, err := net.Dial("tcp", strings.TrimPrefix(strings.TrimSuffix(baseURL, "/api/v1alpha1"), "http://"))
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+			_, err = io.WriteString(conn, "GET /api/v1alpha1/health HTTP/1.1\r\nHost: localhost\r\n")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(conn.SetReadDeadline(time.Now().Add(2 * time.Second))).To(Succeed())
+			started := time.Now()
+			_, err = conn.Read(make([]byte, 1))
+			Expect(err).To(MatchError(io.EOF))
+			Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+		})
+
+		It("cuts off requests still running at the shutdown timeout", func() {
+			entered := make(chan struct{})
+			release := make(chan struct{})
+			DeferCleanup(func() { close(release) })
+			slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(entered)
+				<-release
+			})
+			var stopped atomic.Bool
+			cfg.Service.ShutdownTimeout = 100 * time.Millisecond
+			opts = append(opts, apiserver.WithMetrics(slow), apiserver.OnShutdown(func() { stopped.Store(true) }))
+			start()
+
+			failed := make(chan error, 1)
+			go func() {
+				resp, err := http.Get(strings.TrimSuffix(baseURL, "/api/v1alpha1") + "/metrics")
+				if err == nil {
+					resp.Body.Close()
+				}
+				failed <- err
+			}()
+			Eventually(entered).Should(BeClosed())
+
+			cancel()
+			Eventually(stopped.Load, "2s").Should(BeTrue())
+			Eventually(failed).Should(Receive(HaveOccurred()))
+		})
+	})
+
+	Describe("error content type negotiation", func() {
+		getMissingProvider := func(accept string) (*http.Response, server.Error) {
+			req, err := http.NewRequest(http.MethodGet, baseURL+"/providers/"+uuid.NewString(), nil)
+			Expect(err).NotTo(HaveOccurred())
+			if accept != "" {
+				req.Header.Set("Accept", accept)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			var body server.Error
+			Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+			return resp, body
+		}
+
+		BeforeEach(func() {
+			start()
+		})
+
+		It("defaults to application/problem+json", func() {
+			resp, body := getMissingProvider("")
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/problem+json"))
+			Expect(body.Type).To(Equal("not-found"))
+		})
+
+		It("keeps application/problem+json when the client accepts it", func() {
+			resp, _ := getMissingProvider("application/problem+json, application/json;q=0.5")
+
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/problem+json"))
+		})
+
+		It("serves application/json with the same fields when problem+json is not accepted", func() {
+			resp, body := getMissingProvider("application/json")
+
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(body.Type).To(Equal("not-found"))
+			Expect(body.Title).To(Equal("Provider not found"))
+			Expect(*body.Status).To(Equal(http.StatusNotFound))
+			Expect(body.Detail).NotTo(BeNil())
+		})
+
+		It("treats a zero quality as not accepted", func() {
+			resp, _ := getMissingProvider("application/json, application/problem+json;q=0")
+
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+		})
+	})
+})
+
+var _ = Describe("VerifyRoutes", func() {
+	const baseURL = "/api/v1alpha1"
+
+	It("accepts the generated routes", func() {
+		swagger, err := v1alpha1.GetSwagger()
+		Expect(err).NotTo(HaveOccurred())
+		router := chi.NewRouter()
+		server.HandlerFromMuxWithBaseURL(server.NewStrictHandler(handlers.NewHandler(nil, nil, nil), nil), router, baseURL)
+
+		Expect(apiserver.VerifyRoutes(router, swagger, baseURL)).To(Succeed())
+	})
+
+	It("reports operations that have no route", func() {
+		swagger, err := v1alpha1.GetSwagger()
+		Expect(err).NotTo(HaveOccurred())
+		router := chi.NewRouter()
+		router.Get(baseURL+"/health", func(http.ResponseWriter, *http.Request) {})
+
+		err = apiserver.VerifyRoutes(router, swagger, baseURL)
+
+		Expect(err).To(MatchError(ContainSubstring("GET /api/v1alpha1/providers (ListProviders)")))
+		Expect(err).NotTo(MatchError(ContainSubstring("(GetHealth)")))
+	})
+
+	It("reports routes mounted under a different base URL", func() {
+		swagger, err := v1alpha1.GetSwagger()
+		Expect(err).NotTo(HaveOccurred())
+		router := chi.NewRouter()
+		server.HandlerFromMuxWithBaseURL(server.NewStrictHandler(handlers.NewHandler(nil, nil, nil), nil), router, "/api/v2")
+
+		Expect(apiserver.VerifyRoutes(router, swagger, baseURL)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Run", func() {
+	var (
+		cfg      *config.Config
+		listener net.Listener
+	)
+
+	BeforeEach(func() {
+		cfg = &config.Config{Service: &config.ServiceConfig{}}
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		// Run closes the listener when it has served, but not when it failed to start.
+		DeferCleanup(func() { _ = listener.Close() })
+	})
+
+	// startupError runs the server and returns the error it fails to start with.
+	startupError := func(handler server.StrictServerInterface) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		done := make(chan error, 1)
+		go func() { done <- apiserver.New(cfg, listener, handler).Run(ctx) }()
+		var err error
+		Eventually(done).Should(Receive(&err))
+		return err
+	}
+
+	It("fails at startup with a stub handler", func() {
+		err := startupError(stubHandler{})
+
+		Expect(err).To(MatchError(ContainSubstring("provider API handler apiserver_test.stubHandler embeds a nil")))
+	})
+
+	It("fails at startup without a handler", func() {
+		Expect(startupError(nil)).To(MatchError("provider API has no handler"))
+	})
+})
+
+// stubHandler leaves every operation to the nil interface it embeds.
+type stubHandler struct {
+	server.StrictServerInterface
+}
@@ -11,6 +11,19 @@ type Config struct {
 	Database    *DBConfig
 	Service     *ServiceConfig
 	HealthCheck *HealthCheckConfig
+	Cache       *CacheConfig
+	Reconciler  *ReconcilerConfig
+}
+
+type ReconcilerConfig struct {
+	Interval         time.Duration `envconfig:"RECONCILE_INTERVAL" default:"30s"`
+	Timeout          time.Duration `envconfig:"RECONCILE_TIMEOUT" default:"10s"`
+	TerminalStatuses []string      `envconfig:"RECONCILE_TERMINAL_STATUSES" default:"RUNNING,FAILED,DELETED"`
+}
+
+type CacheConfig struct {
+	Size int           `envconfig:"CACHE_SIZE" default:"0"`
+	TTL  time.Duration `envconfig:"CACHE_TTL" default:"30s"`
 }
 
 type HealthCheckConfig struct {
@@ -19,6 +32,16 @@ type HealthCheckConfig struct {
 	MaxConsecutiveFailures int           `envconfig:"HEALTH_CHECK_MAX_CONSECUTIVE_FAILURES" default:"3"`
 	BaseBackoffInterval    time.Duration `envconfig:"HEALTH_CHECK_BASE_BACKOFF_INTERVAL" default:"10s"`
 	MaxBackoffInterval     time.Duration `envconfig:"HEALTH_CHECK_MAX_BACKOFF_INTERVAL" default:"5m"`
+	Concurrency            int           `envconfig:"HEALTH_CHECK_CONCURRENCY" default:"10"`
+	HistorySize            int           `envconfig:"HEALTH_CHECK_HISTORY_SIZE" default:"100"`
+	CertExpiryWarning      time.Duration `envconfig:"HEALTH_CHECK_CERT_EXPIRY_WARNING" default:"336h"`
+	CertExpiryFails        bool          `envconfig:"HEALTH_CHECK_CERT_EXPIRY_FAILS" default:"false"`
+
+	// Webhook notified of health status changes, disabled when no URL is set
+	WebhookURL           string        `envconfig:"HEALTH_CHECK_WEBHOOK_URL"`
+	WebhookSecret        string        `envconfig:"HEALTH_CHECK_WEBHOOK_SECRET"`
+	WebhookMaxAttempts   int           `envconfig:"HEALTH_CHECK_WEBHOOK_MAX_ATTEMPTS" default:"3"`
+	WebhookRetryInterval time.Duration `envconfig:"HEALTH_CHECK_WEBHOOK_RETRY_INTERVAL" default:"1s"`
 }
 
 type DBConfig struct {
@@ -26,13 +49,51 @@ type DBConfig struct {
 	Hostname string `envconfig:"DB_HOST" default:"localhost"`
 	Port     string `envconfig:"DB_PORT" default:"5432"`
 	Name     string `envconfig:"DB_NAME" default:"service-provider"`
+	// Path is the SQLite database file, or ":memory:" for an in-memory database. When
+	// empty, an existing file named Name is opened, or else ./spm.db.
+	Path     string `envconfig:"DB_PATH"`
 	User     string `envconfig:"DB_USER"`
 	Password string `envconfig:"DB_PASS"`
+
+	MaxOpenConns    int           `envconfig:"DB_MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `envconfig:"DB_MAX_IDLE_CONNS" default:"5"`
+	ConnMaxLifetime time.Duration `envconfig:"DB_CONN_MAX_LIFETIME" default:"30m"`
 }
 
 type ServiceConfig struct {
-	Address  string `envconfig:"SVC_ADDRESS" default:":8080"`
-	LogLevel string `envconfig:"SVC_LOG_LEVEL" default:"info"`
+	Address               string        `envconfig:"SVC_ADDRESS" default:":8080"`
+	LogLevel              string        `envconfig:"SVC_LOG_LEVEL" default:"info"`
+	AdminToken            string        `envconfig:"SVC_ADMIN_TOKEN"`
+	APIKeys               []string      `envconfig:"SVC_API_KEYS"`
+	RateLimit             float64       `envconfig:"SVC_RATE_LIMIT" default:"0"`
+	RateLimitBurst        int           `envconfig:"SVC_RATE_LIMIT_BURST" default:"20"`
+	EndpointSchemePolicy  string        `envconfig:"SVC_ENDPOINT_SCHEME_POLICY" default:"allow-http"`
+	ProviderHostAllowlist []string      `envconfig:"SVC_PROVIDER_HOST_ALLOWLIST"`
+	ProviderCAFile        string        `envconfig:"SVC_PROVIDER_CA_FILE"`
+	ProviderTLSInsecure   bool          `envconfig:"SVC_PROVIDER_TLS_INSECURE_SKIP_VERIFY" default:"false"`
+	StrictPageTokens      bool          `envconfig:"SVC_STRICT_PAGE_TOKENS" default:"false"`
+	DefaultPageSize       int           `envconfig:"SVC_DEFAULT_PAGE_SIZE" default:"100"`
+	MaxPageSize           int           `envconfig:"SVC_MAX_PAGE_SIZE" default:"100"`
+	RequestTimeout        time.Duration `envconfig:"SVC_REQUEST_TIMEOUT" default:"60s"`
+	SchemaVersions        []string      `envconfig:"SVC_SCHEMA_VERSIONS" default:"v1alpha1"`
+
+	// HTTP server timeouts; 0 disables a limit
+	ReadHeaderTimeout time.Duration `envconfig:"SVC_READ_HEADER_TIMEOUT" default:"10s"`
+	ReadTimeout       time.Duration `envconfig:"SVC_READ_TIMEOUT" default:"30s"`
+	WriteTimeout      time.Duration `envconfig:"SVC_WRITE_TIMEOUT" default:"90s"`
+	IdleTimeout       time.Duration `envconfig:"SVC_IDLE_TIMEOUT" default:"120s"`
+	ShutdownTimeout   time.Duration `envconfig:"SVC_SHUTDOWN_TIMEOUT" default:"5s"`
+
+	// Request and response body logging, for debugging provider integrations
+	LogBodies             bool     `envconfig:"SVC_LOG_BODIES" default:"false"`
+	LogBodiesRedactFields []string `envconfig:"SVC_LOG_BODIES_REDACT_FIELDS" default:"token,password,secret"`
+	LogBodiesMaxBytes     int      `envconfig:"SVC_LOG_BODIES_MAX_BYTES" default:"4096"`
+
+	// Cross-origin requests from browsers, disabled when no origin is allowed
+	CORSAllowedOrigins   []string `envconfig:"SVC_CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods   []string `envconfig:"SVC_CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE"`
+	CORSAllowedHeaders   []string `envconfig:"SVC_CORS_ALLOWED_HEADERS" default:"Authorization,Content-Type,If-Match,X-API-Key"`
+	CORSAllowCredentials bool     `envconfig:"SVC_CORS_ALLOW_CREDENTIALS" default:"false"`
 }
 
 func Load() (*Config, error) {
@@ -0,0 +1,25 @@
+package config_test
+
+import (
+	"github.com/dcm-project/service-provider-manager/internal/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Load", func() {
+	It("allows the headers the API reads in cross-origin requests by default", func() {
+		cfg, err := config.Load()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Service.CORSAllowedHeaders).To(ConsistOf("Authorization", "Content-Type", "If-Match", "X-API-Key"))
+	})
+
+	It("keeps the configured CORS headers", func() {
+		GinkgoT().Setenv("SVC_CORS_ALLOWED_HEADERS", "Content-Type")
+
+		cfg, err := config.Load()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Service.CORSAllowedHeaders).To(Equal([]string{"Content-Type"}))
+	})
+})
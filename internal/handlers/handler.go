@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/dcm-project/service-provider-manager/internal/api/server"
 	"github.com/dcm-project/service-provider-manager/internal/service"
@@ -10,38 +12,72 @@ import (
 // Handler implements the generated StrictServerInterface for the Provider API.
 type Handler struct {
 	providerService *service.ProviderService
+	adminService    *service.AdminService
+	healthService   *service.HealthService
 }
 
-// NewHandler creates a new Handler with the given provider service.
-func NewHandler(providerService *service.ProviderService) *Handler {
-	return &Handler{providerService: providerService}
+// NewHandler creates a new Handler with the given provider, admin and health services.
+func NewHandler(providerService *service.ProviderService, adminService *service.AdminService, healthService *service.HealthService) *Handler {
+	return &Handler{
+		providerService: providerService,
+		adminService:    adminService,
+		healthService:   healthService,
+	}
 }
 
 // Ensure Handler implements StrictServerInterface
 var _ server.StrictServerInterface = (*Handler)(nil)
 
 func (h *Handler) GetHealth(ctx context.Context, request server.GetHealthRequestObject) (server.GetHealthResponseObject, error) {
-	status := "ok"
-	path := "health"
-	return server.GetHealth200JSONResponse{Status: &status, Path: &path}, nil
+	return server.GetHealth200JSONResponse(*h.healthService.GetHealth(ctx)), nil
+}
+
+func (h *Handler) GetLiveness(ctx context.Context, request server.GetLivenessRequestObject) (server.GetLivenessResponseObject, error) {
+	return server.GetLiveness200JSONResponse(*h.healthService.GetLiveness()), nil
+}
+
+func (h *Handler) GetReadiness(ctx context.Context, request server.GetReadinessRequestObject) (server.GetReadinessResponseObject, error) {
+	health, ready := h.healthService.GetReadiness(ctx)
+	if !ready {
+		return server.GetReadiness503JSONResponse(*health), nil
+	}
+	return server.GetReadiness200JSONResponse(*health), nil
 }
 
 func (h *Handler) ListProviders(ctx context.Context, request server.ListProvidersRequestObject) (server.ListProvidersResponseObject, error) {
 	var serviceType string
+	var healthStatus string
+	var nameContains string
+	var labels string
 	var maxPageSize int
 	var pageToken string
+	var orderBy string
 
 	if request.Params.Type != nil {
 		serviceType = *request.Params.Type
 	}
+	if request.Params.HealthStatus != nil {
+		healthStatus = string(*request.Params.HealthStatus)
+	}
+	if request.Params.NameContains != nil {
+		nameContains = *request.Params.NameContains
+	}
+	if request.Params.Labels != nil {
+		labels = *request.Params.Labels
+	}
 	if request.Params.MaxPageSize != nil {
 		maxPageSize = *request.Params.MaxPageSize
 	}
 	if request.Params.PageToken != nil {
 		pageToken = *request.Params.PageToken
 	}
+	if request.Params.OrderBy != nil {
+		orderBy = *request.Params.OrderBy
+	}
+	includeCounts := request.Params.IncludeCounts != nil && *request.Params.IncludeCounts
+	skipTotalSize := request.Params.SkipTotalSize != nil && *request.Params.SkipTotalSize
 
-	result, err := h.providerService.ListProviders(ctx, serviceType, maxPageSize, pageToken)
+	result, err := h.providerService.ListProviders(ctx, serviceType, healthStatus, nameContains, labels, maxPageSize, pageToken, orderBy, includeCounts, skipTotalSize)
 	if err != nil {
 		if svcErr, ok := err.(*service.ServiceError); ok && svcErr.Code == service.ErrCodeValidation {
 			return server.ListProviders400ApplicationProblemPlusJSONResponse(newError("validation-error", "Invalid request", svcErr.Message, 400)), nil
@@ -49,7 +85,7 @@ func (h *Handler) ListProviders(ctx context.Context, request server.ListProvider
 		return server.ListProviders400ApplicationProblemPlusJSONResponse(newError("list-error", "Failed to list providers", err.Error(), 400)), nil
 	}
 
-	response := server.ListProviders200JSONResponse{Providers: &result.Providers}
+	response := server.ListProviders200JSONResponse{Providers: &result.Providers, TotalSize: result.TotalSize}
 	if result.NextPageToken != "" {
 		response.NextPageToken = &result.NextPageToken
 	}
@@ -58,7 +94,8 @@ func (h *Handler) ListProviders(ctx context.Context, request server.ListProvider
 }
 
 func (h *Handler) CreateProvider(ctx context.Context, request server.CreateProviderRequestObject) (server.CreateProviderResponseObject, error) {
-	response, err := h.providerService.RegisterOrUpdateProvider(ctx, request.Body, request.Params.Id)
+	validateEndpoint := request.Params.ValidateEndpoint != nil && *request.Params.ValidateEndpoint
+	response, err := h.providerService.RegisterOrUpdateProvider(ctx, request.Body, request.Params.Id, validateEndpoint)
 	if err != nil {
 		if svcErr, ok := err.(*service.ServiceError); ok {
 			switch svcErr.Code {
@@ -66,6 +103,8 @@ func (h *Handler) CreateProvider(ctx context.Context, request server.CreateProvi
 				return server.CreateProvider400ApplicationProblemPlusJSONResponse(newError("validation-error", "Validation failed", svcErr.Message, 400)), nil
 			case service.ErrCodeConflict:
 				return server.CreateProvider409ApplicationProblemPlusJSONResponse(newError("conflict", "Resource conflict", svcErr.Message, 409)), nil
+			case service.ErrCodeProviderError:
+				return server.CreateProvider422ApplicationProblemPlusJSONResponse(newError("endpoint-unreachable", "Endpoint validation failed", svcErr.Message, 422)), nil
 			}
 		}
 		return server.CreateProvider400ApplicationProblemPlusJSONResponse(newError("create-error", "Failed to create provider", err.Error(), 400)), nil
@@ -86,18 +125,47 @@ func (h *Handler) GetProvider(ctx context.Context, request server.GetProviderReq
 		return server.GetProvider400ApplicationProblemPlusJSONResponse(newError("get-error", "Failed to get provider", err.Error(), 400)), nil
 	}
 
-	return server.GetProvider200JSONResponse(*provider), nil
+	return server.GetProvider200JSONResponse{
+		Body:    *provider,
+		Headers: server.GetProvider200ResponseHeaders{ETag: providerETag(provider)},
+	}, nil
+}
+
+func (h *Handler) GetProviderByName(ctx context.Context, request server.GetProviderByNameRequestObject) (server.GetProviderByNameResponseObject, error) {
+	provider, err := h.providerService.GetProviderByName(ctx, request.Params.Name)
+	if err != nil {
+		if svcErr, ok := err.(*service.ServiceError); ok && svcErr.Code == service.ErrCodeNotFound {
+			return server.GetProviderByName404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
+		}
+		return server.GetProviderByName400ApplicationProblemPlusJSONResponse(newError("get-error", "Failed to get provider", err.Error(), 400)), nil
+	}
+
+	return server.GetProviderByName200JSONResponse{
+		Body:    *provider,
+		Headers: server.GetProviderByName200ResponseHeaders{ETag: providerETag(provider)},
+	}, nil
 }
 
 func (h *Handler) ApplyProvider(ctx context.Context, request server.ApplyProviderRequestObject) (server.ApplyProviderResponseObject, error) {
-	provider, err := h.providerService.UpdateProvider(ctx, request.ProviderId.String(), request.Body)
+	validateEndpoint := request.Params.ValidateEndpoint != nil && *request.Params.ValidateEndpoint
+	if request.Params.IfMatch != nil {
+		version, ok := parseIfMatch(*request.Params.IfMatch)
+		if !ok {
+			return server.ApplyProvider400ApplicationProblemPlusJSONResponse(newError("validation-error", "Invalid request", "If-Match must be a provider version or *", 400)), nil
+		}
+		request.Body.Version = version
+	}
+
+	provider, err := h.providerService.UpdateProvider(ctx, request.ProviderId.String(), request.Body, validateEndpoint)
 	if err != nil {
 		if svcErr, ok := err.(*service.ServiceError); ok {
 			switch svcErr.Code {
 			case service.ErrCodeNotFound:
 				return server.ApplyProvider404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
 			case service.ErrCodeConflict:
-				return server.ApplyProvider409ApplicationProblemPlusJSONResponse(newError("conflict", "Name conflict", svcErr.Message, 409)), nil
+				return server.ApplyProvider409ApplicationProblemPlusJSONResponse(newError("conflict", "Conflict", svcErr.Message, 409)), nil
+			case service.ErrCodeProviderError:
+				return server.ApplyProvider422ApplicationProblemPlusJSONResponse(newError("endpoint-unreachable", "Endpoint validation failed", svcErr.Message, 422)), nil
 			}
 		}
 		return server.ApplyProvider400ApplicationProblemPlusJSONResponse(newError("update-error", "Failed to update provider", err.Error(), 400)), nil
@@ -106,18 +174,164 @@ func (h *Handler) ApplyProvider(ctx context.Context, request server.ApplyProvide
 	return server.ApplyProvider200JSONResponse(*provider), nil
 }
 
+func (h *Handler) PatchProvider(ctx context.Context, request server.PatchProviderRequestObject) (server.PatchProviderResponseObject, error) {
+	if request.Params.IfMatch != nil {
+		version, ok := parseIfMatch(*request.Params.IfMatch)
+		if !ok {
+			return server.PatchProvider400ApplicationProblemPlusJSONResponse(newError("validation-error", "Invalid request", "If-Match must be a provider version or *", 400)), nil
+		}
+		request.Body.Version = version
+	}
+
+	provider, err := h.providerService.PatchProvider(ctx, request.ProviderId.String(), request.Body)
+	if err != nil {
+		if svcErr, ok := err.(*service.ServiceError); ok {
+			switch svcErr.Code {
+			case service.ErrCodeNotFound:
+				return server.PatchProvider404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
+			case service.ErrCodeConflict:
+				return server.PatchProvider409ApplicationProblemPlusJSONResponse(newError("conflict", "Conflict", svcErr.Message, 409)), nil
+			case service.ErrCodeValidation:
+				return server.PatchProvider400ApplicationProblemPlusJSONResponse(newError("validation-error", "Validation failed", svcErr.Message, 400)), nil
+			}
+		}
+		return server.PatchProvider400ApplicationProblemPlusJSONResponse(newError("update-error", "Failed to update provider", err.Error(), 400)), nil
+	}
+
+	return server.PatchProvider200JSONResponse(*provider), nil
+}
+
 func (h *Handler) DeleteProvider(ctx context.Context, request server.DeleteProviderRequestObject) (server.DeleteProviderResponseObject, error) {
-	err := h.providerService.DeleteProvider(ctx, request.ProviderId.String())
+	force := request.Params.Force != nil && *request.Params.Force
+	dryRun := request.Params.DryRun != nil && *request.Params.DryRun
+
+	var preview *server.ProviderDeletePreview
+	var err error
+	if dryRun {
+		preview, err = h.providerService.PreviewDeleteProvider(ctx, request.ProviderId.String(), force)
+	} else {
+		err = h.providerService.DeleteProvider(ctx, request.ProviderId.String(), force)
+	}
 	if err != nil {
-		if svcErr, ok := err.(*service.ServiceError); ok && svcErr.Code == service.ErrCodeNotFound {
-			return server.DeleteProvider404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
+		if svcErr, ok := err.(*service.ServiceError); ok {
+			switch svcErr.Code {
+			case service.ErrCodeNotFound:
+				return server.DeleteProvider404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
+			case service.ErrCodeConflict:
+				return server.DeleteProvider409ApplicationProblemPlusJSONResponse(newError("conflict", "Provider has instances", svcErr.Message, 409)), nil
+			}
 		}
 		return server.DeleteProvider400ApplicationProblemPlusJSONResponse(newError("delete-error", "Failed to delete provider", err.Error(), 400)), nil
 	}
 
+	if preview != nil {
+		return server.DeleteProvider200JSONResponse(*preview), nil
+	}
 	return server.DeleteProvider204Response{}, nil
 }
 
+func (h *Handler) RestoreProvider(ctx context.Context, request server.RestoreProviderRequestObject) (server.RestoreProviderResponseObject, error) {
+	provider, err := h.providerService.RestoreProvider(ctx, request.ProviderId.String())
+	if err != nil {
+		if svcErr, ok := err.(*service.ServiceError); ok {
+			switch svcErr.Code {
+			case service.ErrCodeNotFound:
+				return server.RestoreProvider404ApplicationProblemPlusJSONResponse(newError("not-found", "Deleted provider not found", svcErr.Message, 404)), nil
+			case service.ErrCodeConflict:
+				return server.RestoreProvider409ApplicationProblemPlusJSONResponse(newError("conflict", "Name conflict", svcErr.Message, 409)), nil
+			}
+		}
+		return server.RestoreProvider400ApplicationProblemPlusJSONResponse(newError("restore-error", "Failed to restore provider", err.Error(), 400)), nil
+	}
+
+	return server.RestoreProvider200JSONResponse(*provider), nil
+}
+
+func (h *Handler) ListProviderHealthEvents(ctx context.Context, request server.ListProviderHealthEventsRequestObject) (server.ListProviderHealthEventsResponseObject, error) {
+	var limit int
+	if request.Params.Limit != nil {
+		limit = *request.Params.Limit
+	}
+
+	events, err := h.providerService.ListHealthEvents(ctx, request.ProviderId.String(), limit)
+	if err != nil {
+		if svcErr, ok := err.(*service.ServiceError); ok {
+			switch svcErr.Code {
+			case service.ErrCodeNotFound:
+				return server.ListProviderHealthEvents404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
+			case service.ErrCodeValidation:
+				return server.ListProviderHealthEvents400ApplicationProblemPlusJSONResponse(newError("validation-error", "Invalid request", svcErr.Message, 400)), nil
+			}
+		}
+		return server.ListProviderHealthEventsdefaultApplicationProblemPlusJSONResponse{
+			Body:       newError("health-events-error", "Failed to list health events", err.Error(), 500),
+			StatusCode: 500,
+		}, nil
+	}
+
+	return server.ListProviderHealthEvents200JSONResponse(*events), nil
+}
+
+func (h *Handler) CheckProviderHealth(ctx context.Context, request server.CheckProviderHealthRequestObject) (server.CheckProviderHealthResponseObject, error) {
+	result, err := h.providerService.CheckProviderHealth(ctx, request.ProviderId.String())
+	if err != nil {
+		if svcErr, ok := err.(*service.ServiceError); ok && svcErr.Code == service.ErrCodeNotFound {
+			return server.CheckProviderHealth404ApplicationProblemPlusJSONResponse(newError("not-found", "Provider not found", svcErr.Message, 404)), nil
+		}
+		return server.CheckProviderHealthdefaultApplicationProblemPlusJSONResponse{
+			Body:       newError("health-check-error", "Failed to check provider health", err.Error(), 500),
+			StatusCode: 500,
+		}, nil
+	}
+
+	return server.CheckProviderHealth200JSONResponse(*result), nil
+}
+
+func (h *Handler) CheckProvidersHealth(ctx context.Context, request server.CheckProvidersHealthRequestObject) (server.CheckProvidersHealthResponseObject, error) {
+	results, err := h.providerService.CheckProvidersHealth(ctx, request.Body.Ids)
+	if err != nil {
+		if svcErr, ok := err.(*service.ServiceError); ok && svcErr.Code == service.ErrCodeValidation {
+			return server.CheckProvidersHealth400ApplicationProblemPlusJSONResponse(newError("validation-error", "Invalid request", svcErr.Message, 400)), nil
+		}
+		return server.CheckProvidersHealthdefaultApplicationProblemPlusJSONResponse{
+			Body:       newError("health-check-error", "Failed to check provider health", err.Error(), 500),
+			StatusCode: 500,
+		}, nil
+	}
+
+	return server.CheckProvidersHealth200JSONResponse(*results), nil
+}
+
+func (h *Handler) ListSchemaVersions(ctx context.Context, request server.ListSchemaVersionsRequestObject) (server.ListSchemaVersionsResponseObject, error) {
+	return server.ListSchemaVersions200JSONResponse{SchemaVersions: h.providerService.SchemaVersions()}, nil
+}
+
+func (h *Handler) CheckSchema(ctx context.Context, request server.CheckSchemaRequestObject) (server.CheckSchemaResponseObject, error) {
+	status, err := h.adminService.CheckSchema(ctx)
+	if err != nil {
+		return server.CheckSchemadefaultApplicationProblemPlusJSONResponse{
+			Body:       newError("schema-check-error", "Failed to check schema", err.Error(), 500),
+			StatusCode: 500,
+		}, nil
+	}
+
+	return server.CheckSchema200JSONResponse(*status), nil
+}
+
+func (h *Handler) MigrateSchema(ctx context.Context, request server.MigrateSchemaRequestObject) (server.MigrateSchemaResponseObject, error) {
+	allowDestructive := request.Params.AllowDestructive != nil && *request.Params.AllowDestructive
+
+	status, err := h.adminService.MigrateSchema(ctx, allowDestructive)
+	if err != nil {
+		return server.MigrateSchemadefaultApplicationProblemPlusJSONResponse{
+			Body:       newError("schema-migrate-error", "Failed to migrate schema", err.Error(), 500),
+			StatusCode: 500,
+		}, nil
+	}
+
+	return server.MigrateSchema200JSONResponse(*status), nil
+}
+
 func newError(errType, title, detail string, status int) server.Error {
 	return server.Error{
 		Type:   errType,
@@ -126,3 +340,26 @@ func newError(errType, title, detail string, status int) server.Error {
 		Status: &status,
 	}
 }
+
+// providerETag returns the provider's version as an entity tag, which parseIfMatch
+// accepts back.
+func providerETag(provider *server.Provider) string {
+	if provider.Version == nil {
+		return ""
+	}
+	return strconv.Quote(strconv.Itoa(*provider.Version))
+}
+
+// parseIfMatch parses an If-Match header holding a provider version, quoted as an
+// entity tag or not. "*" matches any version and yields nil.
+func parseIfMatch(header string) (*int, bool) {
+	tag := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+	if tag == "*" {
+		return nil, true
+	}
+	version, err := strconv.Atoi(strings.Trim(tag, `"`))
+	if err != nil {
+		return nil, false
+	}
+	return &version, true
+}
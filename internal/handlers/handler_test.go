@@ -1,7 +1,9 @@
 package handlers_test
 
 import (
+	"bytes"
 	"context"
+	"log/slog"
 
 	"github.com/dcm-project/service-provider-manager/internal/api/server"
 	"github.com/dcm-project/service-provider-manager/internal/handlers"
@@ -30,11 +32,18 @@ var _ = Describe("Handler", func() {
 			Logger: logger.Default.LogMode(logger.Silent),
 		})
 		Expect(err).NotTo(HaveOccurred())
-		Expect(db.AutoMigrate(&model.Provider{})).To(Succeed())
+		Expect(db.AutoMigrate(&model.Provider{}, &model.ServiceTypeInstance{}, &model.HealthEvent{})).To(Succeed())
 
 		dataStore := store.NewStore(db)
 		providerService := service.NewProviderService(dataStore)
-		handler = handlers.NewHandler(providerService)
+		adminService := service.NewAdminService(dataStore)
+		healthService := service.NewHealthService(map[string]service.HealthChecker{
+			"database": service.HealthCheckFunc(dataStore.Ping),
+		}, map[string]service.HealthChecker{
+			"database": service.HealthCheckFunc(dataStore.Ping),
+			"schema":   service.SchemaMigrated(dataStore.Schema()),
+		})
+		handler = handlers.NewHandler(providerService, adminService, healthService)
 		ctx = context.Background()
 	})
 
@@ -51,6 +60,77 @@ var _ = Describe("Handler", func() {
 			jsonResp, ok := resp.(server.GetHealth200JSONResponse)
 			Expect(ok).To(BeTrue())
 			Expect(*jsonResp.Status).To(Equal("ok"))
+			Expect(*jsonResp.Components).To(HaveKeyWithValue("database", server.ComponentHealth{Status: "ok"}))
+		})
+
+		It("degrades and reports the failing component when the database is down", func() {
+			sqlDB, _ := db.DB()
+			Expect(sqlDB.Close()).To(Succeed())
+
+			resp, err := handler.GetHealth(ctx, server.GetHealthRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.GetHealth200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(*jsonResp.Status).To(Equal("degraded"))
+			Expect(*jsonResp.Components).To(HaveKey("database"))
+			Expect(*jsonResp.Components).To(HaveKeyWithValue("database", server.ComponentHealth{Status: "error"}))
+		})
+	})
+
+	Describe("GetLiveness", func() {
+		It("returns ok even when the database is down", func() {
+			sqlDB, _ := db.DB()
+			Expect(sqlDB.Close()).To(Succeed())
+
+			resp, err := handler.GetLiveness(ctx, server.GetLivenessRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.GetLiveness200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(*jsonResp.Status).To(Equal("ok"))
+		})
+	})
+
+	Describe("GetReadiness", func() {
+		It("returns 200 when the database is reachable and migrated", func() {
+			resp, err := handler.GetReadiness(ctx, server.GetReadinessRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.GetReadiness200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(*jsonResp.Status).To(Equal("ok"))
+			Expect(*jsonResp.Components).To(HaveKeyWithValue("schema", server.ComponentHealth{Status: "ok"}))
+		})
+
+		It("returns 503 with the failing component when the database is closed", func() {
+			sqlDB, _ := db.DB()
+			Expect(sqlDB.Close()).To(Succeed())
+
+			resp, err := handler.GetReadiness(ctx, server.GetReadinessRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.GetReadiness503JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(*jsonResp.Status).To(Equal("not_ready"))
+			Expect(*jsonResp.Components).To(HaveKeyWithValue("database", server.ComponentHealth{Status: "error"}))
+		})
+
+		It("returns 503 when the migrations have not been applied, logging the missing tables", func() {
+			var logs bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+			DeferCleanup(slog.SetDefault, previous)
+			Expect(db.Migrator().DropTable(&model.ServiceTypeInstance{})).To(Succeed())
+
+			resp, err := handler.GetReadiness(ctx, server.GetReadinessRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.GetReadiness503JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(*jsonResp.Components).To(HaveKeyWithValue("schema", server.ComponentHealth{Status: "error"}))
+			Expect(logs.String()).To(ContainSubstring("component=schema"))
+			Expect(logs.String()).To(ContainSubstring("missing tables"))
 		})
 	})
 
@@ -72,6 +152,23 @@ var _ = Describe("Handler", func() {
 			Expect(ok).To(BeTrue())
 		})
 
+		It("returns 422 when the endpoint fails validation", func() {
+			validate := true
+			resp, err := handler.CreateProvider(ctx, server.CreateProviderRequestObject{
+				Params: server.CreateProviderParams{ValidateEndpoint: &validate},
+				Body: &server.Provider{
+					Name:          "unreachable-provider",
+					Endpoint:      "http://127.0.0.1:1",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.CreateProvider422ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
+
 		It("returns 200 for idempotent re-registration", func() {
 			req := server.CreateProviderRequestObject{
 				Body: &server.Provider{
@@ -162,6 +259,17 @@ var _ = Describe("Handler", func() {
 			Expect(ok).To(BeTrue())
 			Expect(*jsonResp.Providers).To(HaveLen(2))
 		})
+
+		It("returns 400 for an unknown health_status", func() {
+			status := server.ListProvidersParamsHealthStatus("broken")
+			req := server.ListProvidersRequestObject{Params: server.ListProvidersParams{HealthStatus: &status}}
+
+			resp, err := handler.ListProviders(ctx, req)
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.ListProviders400ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
 	})
 
 	Describe("GetProvider", func() {
@@ -187,7 +295,8 @@ var _ = Describe("Handler", func() {
 			Expect(err).NotTo(HaveOccurred())
 			jsonResp, ok := resp.(server.GetProvider200JSONResponse)
 			Expect(ok).To(BeTrue())
-			Expect(jsonResp.Name).To(Equal("get-me"))
+			Expect(jsonResp.Body.Name).To(Equal("get-me"))
+			Expect(jsonResp.Headers.ETag).To(Equal(`"1"`))
 		})
 
 		It("returns 404 for non-existent provider", func() {
@@ -203,6 +312,40 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("GetProviderByName", func() {
+		It("returns the provider", func() {
+			_, err := handler.CreateProvider(ctx, server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "find-me",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := handler.GetProviderByName(ctx, server.GetProviderByNameRequestObject{
+				Params: server.GetProviderByNameParams{Name: "find-me"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.GetProviderByName200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(jsonResp.Body.Name).To(Equal("find-me"))
+			Expect(jsonResp.Headers.ETag).To(Equal(`"1"`))
+		})
+
+		It("returns 404 for an unknown name", func() {
+			resp, err := handler.GetProviderByName(ctx, server.GetProviderByNameRequestObject{
+				Params: server.GetProviderByNameParams{Name: "unknown"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.GetProviderByName404ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
 	Describe("ApplyProvider", func() {
 		It("updates existing provider", func() {
 			// Create a provider first
@@ -237,6 +380,37 @@ var _ = Describe("Handler", func() {
 			Expect(jsonResp.Endpoint).To(Equal("https://updated.example.com"))
 		})
 
+		It("checks the version given in If-Match", func() {
+			createResp, _ := handler.CreateProvider(ctx, server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "if-match",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+			created := createResp.(server.CreateProvider201JSONResponse)
+			apply := func(ifMatch string) server.ApplyProviderResponseObject {
+				resp, err := handler.ApplyProvider(ctx, server.ApplyProviderRequestObject{
+					ProviderId: *created.Id,
+					Params:     server.ApplyProviderParams{IfMatch: &ifMatch},
+					Body: &server.Provider{
+						Name:          "if-match",
+						Endpoint:      "https://updated.example.com",
+						ServiceType:   "vm",
+						SchemaVersion: "v1alpha1",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return resp
+			}
+
+			Expect(apply(`"1"`)).To(BeAssignableToTypeOf(server.ApplyProvider200JSONResponse{}))
+			Expect(apply(`"1"`)).To(BeAssignableToTypeOf(server.ApplyProvider409ApplicationProblemPlusJSONResponse{}))
+			Expect(apply("*")).To(BeAssignableToTypeOf(server.ApplyProvider200JSONResponse{}))
+			Expect(apply("latest")).To(BeAssignableToTypeOf(server.ApplyProvider400ApplicationProblemPlusJSONResponse{}))
+		})
+
 		It("returns 404 for non-existent provider", func() {
 			req := server.ApplyProviderRequestObject{
 				ProviderId: openapi_types.UUID(uuid.New()),
@@ -254,6 +428,46 @@ var _ = Describe("Handler", func() {
 			_, ok := resp.(server.ApplyProvider404ApplicationProblemPlusJSONResponse)
 			Expect(ok).To(BeTrue())
 		})
+
+		It("returns 422 when the new endpoint fails validation", func() {
+			createResp, _ := handler.CreateProvider(ctx, server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "to-migrate",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+			created := createResp.(server.CreateProvider201JSONResponse)
+
+			validate := true
+			resp, err := handler.ApplyProvider(ctx, server.ApplyProviderRequestObject{
+				ProviderId: *created.Id,
+				Params:     server.ApplyProviderParams{ValidateEndpoint: &validate},
+				Body: &server.Provider{
+					Name:          "to-migrate",
+					Endpoint:      "http://127.0.0.1:1",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.ApplyProvider422ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("CheckProvidersHealth", func() {
+		It("returns 400 for an empty list of IDs", func() {
+			resp, err := handler.CheckProvidersHealth(ctx, server.CheckProvidersHealthRequestObject{
+				Body: &server.CheckProvidersHealthJSONRequestBody{},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.CheckProvidersHealth400ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
 	})
 
 	Describe("DeleteProvider", func() {
@@ -281,6 +495,33 @@ var _ = Describe("Handler", func() {
 			Expect(ok).To(BeTrue())
 		})
 
+		It("returns a preview and keeps the provider on a dry run", func() {
+			createResp, _ := handler.CreateProvider(ctx, server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "dry-run",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+			created := createResp.(server.CreateProvider201JSONResponse)
+			dryRun := true
+
+			resp, err := handler.DeleteProvider(ctx, server.DeleteProviderRequestObject{
+				ProviderId: *created.Id,
+				Params:     server.DeleteProviderParams{DryRun: &dryRun},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			preview, ok := resp.(server.DeleteProvider200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(preview.Provider.Name).To(Equal("dry-run"))
+			Expect(preview.InstanceCount).To(BeZero())
+			getResp, err := handler.GetProvider(ctx, server.GetProviderRequestObject{ProviderId: *created.Id})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getResp).To(BeAssignableToTypeOf(server.GetProvider200JSONResponse{}))
+		})
+
 		It("returns 404 for non-existent provider", func() {
 			req := server.DeleteProviderRequestObject{
 				ProviderId: openapi_types.UUID(uuid.New()),
@@ -293,4 +534,143 @@ var _ = Describe("Handler", func() {
 			Expect(ok).To(BeTrue())
 		})
 	})
+
+	Describe("PatchProvider", func() {
+		It("updates the given fields and returns 200", func() {
+			createReq := server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "to-patch",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			}
+			createResp, _ := handler.CreateProvider(ctx, createReq)
+			created := createResp.(server.CreateProvider201JSONResponse)
+
+			endpoint := "https://patched.example.com"
+			resp, err := handler.PatchProvider(ctx, server.PatchProviderRequestObject{
+				ProviderId: *created.Id,
+				Body:       &server.ProviderPatch{Endpoint: &endpoint},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			patched, ok := resp.(server.PatchProvider200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(patched.Endpoint).To(Equal(endpoint))
+			Expect(patched.Name).To(Equal("to-patch"))
+		})
+
+		It("checks the version given in If-Match", func() {
+			createResp, _ := handler.CreateProvider(ctx, server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "patch-if-match",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			})
+			created := createResp.(server.CreateProvider201JSONResponse)
+			patch := func(ifMatch string) server.PatchProviderResponseObject {
+				endpoint := "https://patched.example.com"
+				resp, err := handler.PatchProvider(ctx, server.PatchProviderRequestObject{
+					ProviderId: *created.Id,
+					Params:     server.PatchProviderParams{IfMatch: &ifMatch},
+					Body:       &server.ProviderPatch{Endpoint: &endpoint},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return resp
+			}
+
+			Expect(patch(`"1"`)).To(BeAssignableToTypeOf(server.PatchProvider200JSONResponse{}))
+			Expect(patch(`"1"`)).To(BeAssignableToTypeOf(server.PatchProvider409ApplicationProblemPlusJSONResponse{}))
+			Expect(patch("*")).To(BeAssignableToTypeOf(server.PatchProvider200JSONResponse{}))
+			Expect(patch("latest")).To(BeAssignableToTypeOf(server.PatchProvider400ApplicationProblemPlusJSONResponse{}))
+		})
+
+		It("returns 404 for non-existent provider", func() {
+			req := server.PatchProviderRequestObject{
+				ProviderId: openapi_types.UUID(uuid.New()),
+				Body:       &server.ProviderPatch{},
+			}
+
+			resp, err := handler.PatchProvider(ctx, req)
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.PatchProvider404ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("RestoreProvider", func() {
+		It("restores a deleted provider and returns 200", func() {
+			createReq := server.CreateProviderRequestObject{
+				Body: &server.Provider{
+					Name:          "to-restore",
+					Endpoint:      "https://example.com",
+					ServiceType:   "vm",
+					SchemaVersion: "v1alpha1",
+				},
+			}
+			createResp, _ := handler.CreateProvider(ctx, createReq)
+			created := createResp.(server.CreateProvider201JSONResponse)
+			handler.DeleteProvider(ctx, server.DeleteProviderRequestObject{ProviderId: *created.Id})
+
+			resp, err := handler.RestoreProvider(ctx, server.RestoreProviderRequestObject{ProviderId: *created.Id})
+
+			Expect(err).NotTo(HaveOccurred())
+			restored, ok := resp.(server.RestoreProvider200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(restored.Name).To(Equal("to-restore"))
+		})
+
+		It("returns 404 for a provider that is not deleted", func() {
+			req := server.RestoreProviderRequestObject{
+				ProviderId: openapi_types.UUID(uuid.New()),
+			}
+
+			resp, err := handler.RestoreProvider(ctx, req)
+
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := resp.(server.RestoreProvider404ApplicationProblemPlusJSONResponse)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("ListSchemaVersions", func() {
+		It("returns the supported schema versions", func() {
+			resp, err := handler.ListSchemaVersions(ctx, server.ListSchemaVersionsRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(server.ListSchemaVersions200JSONResponse{SchemaVersions: []string{"v1alpha1"}}))
+		})
+	})
+
+	Describe("CheckSchema", func() {
+		It("reports the schema in sync after migration", func() {
+			resp, err := handler.CheckSchema(ctx, server.CheckSchemaRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.CheckSchema200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(jsonResp.InSync).To(BeTrue())
+		})
+	})
+
+	Describe("MigrateSchema", func() {
+		It("creates missing columns and reports the result", func() {
+			Expect(db.Migrator().DropColumn(&model.Provider{}, "next_health_check")).To(Succeed())
+
+			checkResp, err := handler.CheckSchema(ctx, server.CheckSchemaRequestObject{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*checkResp.(server.CheckSchema200JSONResponse).MissingColumns).To(ConsistOf("providers.next_health_check"))
+
+			resp, err := handler.MigrateSchema(ctx, server.MigrateSchemaRequestObject{})
+
+			Expect(err).NotTo(HaveOccurred())
+			jsonResp, ok := resp.(server.MigrateSchema200JSONResponse)
+			Expect(ok).To(BeTrue())
+			Expect(jsonResp.InSync).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,85 @@
+package healthcheck
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+)
+
+// redactedHeaders are replaced in debug logs because they carry credentials.
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// debugTransport logs every request sent to a provider and its response, with
+// credentials redacted. It is used for providers with DebugLogging set, and for all
+// providers when debug logging is enabled.
+type debugTransport struct {
+	logger *slog.Logger
+	level  slog.Level
+	// authHeader is the provider's custom credential header, redacted as well
+	authHeader string
+	next       http.RoundTripper
+}
+
+// debugClient returns a copy of client that logs its calls to the provider at level.
+func debugClient(client *http.Client, logger *slog.Logger, level slog.Level, provider model.Provider) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	debug := *client
+	debug.Transport = &debugTransport{
+		logger:     logger.With("provider_name", provider.Name),
+		level:      level,
+		authHeader: provider.AuthHeader,
+		next:       next,
+	}
+	return &debug
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	url := req.URL.Redacted()
+	t.logger.Log(ctx, t.level, "Provider request", "method", req.Method, "url", url, "headers", t.formatHeaders(req.Header))
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.logger.Log(ctx, t.level, "Provider request failed", "method", req.Method, "url", url, "elapsed", elapsed, "error", err)
+		return nil, err
+	}
+
+	t.logger.Log(ctx, t.level, "Provider response", "method", req.Method, "url", url,
+		"status", resp.StatusCode, "elapsed", elapsed, "headers", t.formatHeaders(resp.Header))
+	return resp, nil
+}
+
+// formatHeaders renders headers in a stable order with credentials redacted.
+func (t *debugTransport) formatHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(h[name], ", ")
+		canonical := http.CanonicalHeaderKey(name)
+		if redactedHeaders[canonical] || (t.authHeader != "" && canonical == http.CanonicalHeaderKey(t.authHeader)) {
+			value = "REDACTED"
+		}
+		parts = append(parts, name+": "+value)
+	}
+	return "{" + strings.Join(parts, "; ") + "}"
+}
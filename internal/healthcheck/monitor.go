@@ -2,16 +2,22 @@ package healthcheck
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"math"
 	"net/http"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dcm-project/service-provider-manager/internal/clock"
 	"github.com/dcm-project/service-provider-manager/internal/config"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
 	"github.com/dcm-project/service-provider-manager/internal/store"
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
 )
 
 // Monitor performs periodic health checks on registered service providers
@@ -24,11 +30,69 @@ type Monitor struct {
 	maxConsecutiveFailures int
 	baseBackoffInterval    time.Duration
 	maxBackoffInterval     time.Duration
+	concurrency            int
+	historySize            int
+	certExpiryWarning      time.Duration
+	certExpiryFails        bool
+	webhook                *webhook
+	allowlist              *netpolicy.HostAllowlist
+	clock                  clock.Clock
+	logger                 *slog.Logger
+	metrics                *metrics.Metrics
+	onHealthUpdate         func(providerID uuid.UUID)
+
+	running   atomic.Bool
+	lastCycle atomic.Int64 // unix nanoseconds of the last completed cycle
+}
+
+// MonitorOption configures optional Monitor behavior.
+type MonitorOption func(*Monitor)
+
+// WithHostAllowlist makes the monitor refuse to probe providers whose endpoint host
+// is not on the allowlist. Such providers fail their health check.
+func WithHostAllowlist(allowlist *netpolicy.HostAllowlist) MonitorOption {
+	return func(m *Monitor) {
+		m.allowlist = allowlist
+	}
+}
+
+// WithTransport sets the transport used to reach providers, for example one that
+// trusts a private CA.
+func WithTransport(transport http.RoundTripper) MonitorOption {
+	return func(m *Monitor) {
+		m.httpClient.Transport = transport
+	}
+}
+
+// staleCycleFactor is the number of intervals without a completed cycle after which
+// the monitor reports itself unhealthy.
+const staleCycleFactor = 3
+
+// WithClock sets the time source used to schedule checks.
+func WithClock(c clock.Clock) MonitorOption {
+	return func(m *Monitor) {
+		m.clock = c
+	}
+}
+
+// WithLogger sets the logger. Individual check failures are logged at debug level,
+// health status changes at info level.
+func WithLogger(logger *slog.Logger) MonitorOption {
+	return func(m *Monitor) {
+		m.logger = logger
+	}
+}
+
+// WithMetrics records probe latencies and health status changes in m.
+func WithMetrics(m *metrics.Metrics) MonitorOption {
+	return func(mon *Monitor) {
+		mon.metrics = m
+	}
 }
 
 // NewMonitor creates a new health check monitor
-func NewMonitor(providerStore store.Provider, config *config.HealthCheckConfig) *Monitor {
-	return &Monitor{
+func NewMonitor(providerStore store.Provider, config *config.HealthCheckConfig, opts ...MonitorOption) *Monitor {
+	m := &Monitor{
 		store: providerStore,
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
@@ -38,23 +102,74 @@ func NewMonitor(providerStore store.Provider, config *config.HealthCheckConfig)
 		maxConsecutiveFailures: config.MaxConsecutiveFailures,
 		baseBackoffInterval:    config.BaseBackoffInterval,
 		maxBackoffInterval:     config.MaxBackoffInterval,
+		concurrency:            max(config.Concurrency, 1),
+		historySize:            config.HistorySize,
+		certExpiryWarning:      config.CertExpiryWarning,
+		certExpiryFails:        config.CertExpiryFails,
+		clock:                  clock.Real{},
+		logger:                 slog.Default(),
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if config.WebhookURL != "" {
+		m.webhook = &webhook{
+			url:           config.WebhookURL,
+			secret:        []byte(config.WebhookSecret),
+			client:        &http.Client{Timeout: config.Timeout},
+			maxAttempts:   max(config.WebhookMaxAttempts, 1),
+			retryInterval: config.WebhookRetryInterval,
+			logger:        m.logger,
+			stopCh:        make(chan struct{}),
+		}
+	}
+	return m
+}
+
+// OnHealthUpdate registers hook to be called with the provider's ID after each stored
+// health status update, for example to evict cached copies of the provider. It must
+// be called before Start.
+func (m *Monitor) OnHealthUpdate(hook func(providerID uuid.UUID)) {
+	m.onHealthUpdate = hook
 }
 
 // Start begins the health check monitoring loop
 func (m *Monitor) Start(ctx context.Context) {
+	m.running.Store(true)
 	m.wg.Add(1)
 	go m.run(ctx)
 }
 
-// Stop gracefully stops the health check monitor
+// Stop gracefully stops the health check monitor. Webhook deliveries in progress
+// are finished, but not retried.
 func (m *Monitor) Stop() {
 	close(m.stopCh)
 	m.wg.Wait()
+	if m.webhook != nil {
+		m.webhook.stop()
+	}
+}
+
+// CheckHealth reports an error when the monitor loop is not running or has not
+// completed a cycle within the last few intervals.
+func (m *Monitor) CheckHealth(ctx context.Context) error {
+	if !m.running.Load() {
+		return errors.New("health check monitor is not running")
+	}
+
+	last := m.lastCycle.Load()
+	if last == 0 {
+		return nil
+	}
+	if since := m.clock.Now().Sub(time.Unix(0, last)); since > staleCycleFactor*m.interval {
+		return fmt.Errorf("last health check cycle completed %s ago", since.Round(time.Second))
+	}
+	return nil
 }
 
 func (m *Monitor) run(ctx context.Context) {
 	defer m.wg.Done()
+	defer m.running.Store(false)
 
 	ticker := time.NewTicker(m.interval)
 	defer ticker.Stop()
@@ -74,81 +189,297 @@ func (m *Monitor) run(ctx context.Context) {
 	}
 }
 
-// CheckProviders checks all providers that are due for a health check
+// CheckProviders checks all providers that are due for a health check, up to the
+// configured concurrency at a time, and returns once they are all checked.
 func (m *Monitor) CheckProviders(ctx context.Context) {
-	now := time.Now()
+	now := m.clock.Now()
 	providers, err := m.store.ListProvidersForHealthCheck(ctx, now)
 	if err != nil {
-		log.Printf("Error listing providers for health check: %v", err)
+		m.logger.Error("Error listing providers for health check", "error", err)
 		return
 	}
 
-	for _, provider := range providers {
+	var (
+		work       = make(chan model.Provider)
+		notChecked atomic.Int64
+		wg         sync.WaitGroup
+	)
+	for range min(m.concurrency, len(providers)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for provider := range work {
+				if ctx.Err() != nil {
+					notChecked.Add(1)
+					continue
+				}
+				_, err := m.CheckProvider(ctx, provider)
+				if errors.Is(err, ErrCheckCancelled) {
+					notChecked.Add(1)
+				} else if err != nil {
+					m.logger.Error("Error checking provider health", "provider_name", provider.Name, "error", err)
+				}
+			}
+		}()
+	}
+
+feed:
+	for i, provider := range providers {
 		select {
+		case work <- provider:
 		case <-ctx.Done():
-			return
-		default:
-			m.checkProvider(ctx, provider)
+			notChecked.Add(int64(len(providers) - i))
+			break feed
 		}
 	}
+	close(work)
+	wg.Wait()
+
+	if n := notChecked.Load(); n > 0 {
+		m.logger.Info("Health check cycle cancelled", "not_checked", n, "total", len(providers))
+		return
+	}
+	m.lastCycle.Store(m.clock.Now().UnixNano())
+}
+
+// ErrCheckCancelled is returned by CheckProvider when the context was cancelled
+// before the check finished. The provider's health status is left unchanged.
+var ErrCheckCancelled = errors.New("health check cancelled")
+
+// CheckResult is the outcome of checking a single provider.
+type CheckResult struct {
+	Status              model.HealthStatus
+	ConsecutiveFailures int
+	// Err describes why the health check failed; nil when the provider is healthy.
+	Err error
+	// CertNotAfter is when the certificate of an https endpoint expires.
+	CertNotAfter *time.Time
+	// Warning flags a certificate that expires within the warning window.
+	Warning string
 }
 
-func (m *Monitor) checkProvider(ctx context.Context, provider model.Provider) {
-	now := time.Now()
-	newStatus := model.HealthStatusReady
-	consecutiveFailures := 0
+// CheckProvider checks one provider right away and stores its new health status,
+// exactly as the periodic loop does. The returned error is set only when the status
+// could not be stored or the check was cancelled.
+func (m *Monitor) CheckProvider(ctx context.Context, provider model.Provider) (CheckResult, error) {
+	now := m.clock.Now()
+	result := CheckResult{Status: model.HealthStatusReady}
 
-	healthy := m.performHealthCheck(ctx, provider)
-	if !healthy {
-		consecutiveFailures = provider.ConsecutiveFailures + 1
+	probed, err := m.performHealthCheck(ctx, provider)
+	result.Err = err
+	if result.Err != nil && ctx.Err() != nil {
+		// The check was interrupted, which says nothing about the provider. Only the
+		// HTTP client timeout, which does not cancel ctx, counts as a failure.
+		return CheckResult{}, fmt.Errorf("provider %s: %w", provider.Name, ErrCheckCancelled)
+	}
+	result.CertNotAfter = probed.certNotAfter
+	if expiry := probed.certNotAfter; expiry != nil && m.certExpiryWarning > 0 && expiry.Sub(now) < m.certExpiryWarning {
+		result.Warning = fmt.Sprintf("certificate expires at %s", expiry.UTC().Format(time.RFC3339))
+		m.logger.Warn("Provider certificate expires soon", "provider_name", provider.Name, "not_after", *expiry)
+		if m.certExpiryFails && result.Err == nil {
+			result.Err = errors.New(result.Warning)
+		}
+	}
+	switch {
+	case result.Err == nil:
+	case provider.InMaintenance(now):
+		// Failures during planned maintenance are expected and do not count.
+		m.logger.Debug("Health check failed during maintenance", "provider_name", provider.Name, "error", result.Err)
+		result.Status = model.HealthStatusMaintenance
+		result.ConsecutiveFailures = provider.ConsecutiveFailures
+	default:
+		result.ConsecutiveFailures = provider.ConsecutiveFailures + 1
+		m.logger.Debug("Health check failed", "provider_name", provider.Name, "consecutive_failures", result.ConsecutiveFailures, "error", result.Err)
+
+		result.Status = provider.HealthStatus
+		if result.Status == model.HealthStatusMaintenance {
+			// The window is over; failures count again from where they were.
+			result.Status = model.HealthStatusReady
+		}
+		// A provider that has never passed a check is not given the benefit of the
+		// doubt: its first failure makes it not ready.
+		if result.ConsecutiveFailures >= m.maxConsecutiveFailures || result.Status == model.HealthStatusUnknown {
+			result.Status = model.HealthStatusNotReady
+		}
+	}
+
+	nextCheck := m.CalculateNextCheckTime(now, result.Status, result.ConsecutiveFailures)
+	if err := m.store.UpdateHealthStatus(ctx, provider.ID, result.Status, result.ConsecutiveFailures, nextCheck); err != nil {
+		return result, fmt.Errorf("updating health status for provider %s: %w", provider.Name, err)
+	}
+	if m.onHealthUpdate != nil {
+		m.onHealthUpdate(provider.ID)
+	}
 
-		newStatus = provider.HealthStatus
-		if consecutiveFailures >= m.maxConsecutiveFailures {
-			newStatus = model.HealthStatusNotReady
+	if provider.HealthStatus != result.Status {
+		m.logger.Info("Provider health status changed", "provider_name", provider.Name,
+			"from", provider.HealthStatus, "to", result.Status)
+		// Entering and leaving a maintenance window is planned, so it is not reported
+		// as a health transition.
+		if provider.HealthStatus != model.HealthStatusMaintenance && result.Status != model.HealthStatusMaintenance {
+			m.reportTransition(provider, result.Status, now)
 		}
 	}
+	// Providers with health checks disabled are not probed, so only a change of
+	// their status is worth a history entry.
+	if !provider.HealthCheckDisabled || provider.HealthStatus != result.Status {
+		m.recordEvent(ctx, provider, now, result, probed)
+	}
+	return result, nil
+}
+
+// reportTransition counts the provider's health status change and posts it to the
+// webhook.
+func (m *Monitor) reportTransition(provider model.Provider, status model.HealthStatus, now time.Time) {
+	if m.metrics != nil {
+		m.metrics.HealthTransitions.WithLabelValues(string(provider.HealthStatus), string(status)).Inc()
+	}
+	if m.webhook != nil {
+		m.webhook.notify(HealthTransitionEvent{
+			ProviderID:   provider.ID,
+			ProviderName: provider.Name,
+			OldStatus:    provider.HealthStatus,
+			NewStatus:    status,
+			Timestamp:    now,
+		})
+	}
+}
 
-	nextCheck := m.CalculateNextCheckTime(now, newStatus, consecutiveFailures)
-	if err := m.store.UpdateHealthStatus(ctx, provider.ID, newStatus, consecutiveFailures, nextCheck); err != nil {
-		log.Printf("Error updating health status for provider %s: %v", provider.Name, err)
+// recordEvent adds the check to the provider's health history, keeping the newest
+// historySize events. The history is informational, so failing to store it is only
+// logged.
+func (m *Monitor) recordEvent(ctx context.Context, provider model.Provider, now time.Time, result CheckResult, probed probeResult) {
+	if m.historySize <= 0 {
 		return
 	}
+	event := model.HealthEvent{
+		ProviderID:   provider.ID,
+		CheckTime:    now,
+		Status:       result.Status,
+		HTTPStatus:   probed.status,
+		LatencyMs:    probed.latency.Milliseconds(),
+		CertNotAfter: result.CertNotAfter,
+		Warning:      result.Warning,
+	}
+	if result.Err != nil {
+		event.Error = result.Err.Error()
+	}
+	if err := m.store.RecordHealthEvent(ctx, event, m.historySize); err != nil {
+		m.logger.Error("Error recording health event", "provider_name", provider.Name, "error", err)
+	}
+}
 
-	if provider.HealthStatus != newStatus {
-		log.Printf("Provider %s health status changed: %s -> %s", provider.Name, provider.HealthStatus, newStatus)
+// probeResult describes the response to a health probe.
+type probeResult struct {
+	// status is the status code of the response, 0 when there was none
+	status  int
+	latency time.Duration
+	// certNotAfter is when the server certificate expires, nil over plain http
+	certNotAfter *time.Time
+}
+
+// performHealthCheck probes the provider and describes the response and how long
+// the probe took.
+func (m *Monitor) performHealthCheck(ctx context.Context, provider model.Provider) (probeResult, error) {
+	if provider.HealthCheckDisabled {
+		// Static providers opt out of health checks and always count as healthy.
+		return probeResult{}, nil
+	}
+	if err := m.allowlist.CheckEndpoint(provider.Endpoint); err != nil {
+		return probeResult{}, fmt.Errorf("not contacted: %w", err)
 	}
+	start := time.Now()
+	result, err := probe(ctx, ProviderClient(ctx, m.httpClient, m.logger, provider), provider)
+	result.latency = time.Since(start)
+	if m.metrics != nil {
+		m.metrics.ProviderCallDuration.WithLabelValues(metrics.CallHealthCheck).Observe(result.latency.Seconds())
+	}
+	return result, err
+}
+
+// MaxProviderTimeout bounds a provider's timeout override so a misconfigured provider
+// cannot hold up a health check cycle indefinitely.
+const MaxProviderTimeout = 5 * time.Minute
+
+// ProviderTimeout converts a provider's timeout override to a duration, clamped to
+// MaxProviderTimeout.
+func ProviderTimeout(seconds int) time.Duration {
+	return min(time.Duration(seconds)*time.Second, MaxProviderTimeout)
 }
 
-func (m *Monitor) performHealthCheck(ctx context.Context, provider model.Provider) bool {
-	healthURL := strings.TrimRight(provider.Endpoint, "/") + "/health"
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+// ProviderClient returns a copy of client for calls to the provider, with the
+// provider's timeout override. The calls are logged at info level for providers with
+// DebugLogging set, so they show up whatever the level, and at debug level for all
+// others.
+func ProviderClient(ctx context.Context, client *http.Client, logger *slog.Logger, provider model.Provider) *http.Client {
+	if provider.TimeoutSeconds > 0 {
+		timeout := *client
+		timeout.Timeout = ProviderTimeout(provider.TimeoutSeconds)
+		client = &timeout
+	}
+	if provider.DebugLogging {
+		client = debugClient(client, logger, slog.LevelInfo, provider)
+	} else if logger.Enabled(ctx, slog.LevelDebug) {
+		client = debugClient(client, logger, slog.LevelDebug, provider)
+	}
+	return client
+}
+
+// AuthHeader returns the headers carrying the provider's credentials, or nil when
+// it has none.
+func AuthHeader(provider model.Provider) http.Header {
+	switch provider.AuthType {
+	case model.AuthTypeBearer:
+		return http.Header{"Authorization": {"Bearer " + provider.AuthToken}}
+	case model.AuthTypeHeader:
+		header := http.Header{}
+		header.Set(provider.AuthHeader, provider.AuthToken)
+		return header
+	}
+	return nil
+}
+
+// Probe sends a GET with the provider's credentials to its health URL and returns an
+// error unless the response status counts as healthy for the provider.
+func Probe(ctx context.Context, client *http.Client, provider model.Provider) error {
+	_, err := probe(ctx, client, provider)
+	return err
+}
+
+// probe is Probe, also describing the response.
+func probe(ctx context.Context, client *http.Client, provider model.Provider) (probeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.HealthURL(), nil)
 	if err != nil {
-		log.Printf("Error creating health check request for provider %s: %v", provider.Name, err)
-		return false
+		return probeResult{}, fmt.Errorf("creating health check request: %w", err)
+	}
+	for name, values := range AuthHeader(provider) {
+		req.Header[name] = values
 	}
 
-	resp, err := m.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		log.Printf("Health check failed for provider %s: %v", provider.Name, err)
-		return false
+		return probeResult{}, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		return true
+	result := probeResult{status: resp.StatusCode}
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		notAfter := resp.TLS.PeerCertificates[0].NotAfter
+		result.certNotAfter = &notAfter
 	}
-
-	log.Printf("Health check failed for provider %s: status code %d", provider.Name, resp.StatusCode)
-	return false
+	if !provider.HealthyStatus(resp.StatusCode) {
+		return result, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	return result, nil
 }
 
 // CalculateNextCheckTime determines when the next health check should occur
-// For Ready providers: standard interval (10 seconds)
+// For Ready providers and providers in maintenance: standard interval (10 seconds)
 // Exponential backoff for NotReady providers
 // Formula: min(MaxBackoff, BaseInterval * 2^(failures - MaxConsecutiveFailures))
 // This starts exponential backoff after the provider becomes NotReady
 func (m *Monitor) CalculateNextCheckTime(now time.Time, status model.HealthStatus, consecutiveFailures int) time.Time {
-	if status == model.HealthStatusReady {
+	if status == model.HealthStatusReady || status == model.HealthStatusMaintenance {
 		return now.Add(m.interval)
 	}
 
@@ -1,18 +1,40 @@
 package healthcheck_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dcm-project/service-provider-manager/internal/clock"
 	"github.com/dcm-project/service-provider-manager/internal/config"
 	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
+	"github.com/dcm-project/service-provider-manager/internal/logging"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
 	"github.com/dcm-project/service-provider-manager/internal/store"
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 )
 
 // testHealthCheckConfig returns a default config for testing
@@ -29,7 +51,9 @@ func testHealthCheckConfig() *config.HealthCheckConfig {
 // mockProviderStore implements store.Provider interface for testing
 type mockProviderStore struct {
 	providers           model.ProviderList
+	mu                  sync.Mutex
 	healthStatusUpdates []healthStatusUpdate
+	healthEvents        []model.HealthEvent
 }
 
 type healthStatusUpdate struct {
@@ -50,6 +74,8 @@ func (m *mockProviderStore) ListProvidersForHealthCheck(ctx context.Context, now
 }
 
 func (m *mockProviderStore) UpdateHealthStatus(ctx context.Context, id uuid.UUID, status model.HealthStatus, consecutiveFailures int, nextCheck time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.healthStatusUpdates = append(m.healthStatusUpdates, healthStatusUpdate{
 		ID:                  id,
 		Status:              status,
@@ -59,10 +85,29 @@ func (m *mockProviderStore) UpdateHealthStatus(ctx context.Context, id uuid.UUID
 	return nil
 }
 
+func (m *mockProviderStore) RecordHealthEvent(ctx context.Context, event model.HealthEvent, keep int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.healthEvents = append(m.healthEvents, event)
+	return nil
+}
+
+func (m *mockProviderStore) ListHealthEvents(ctx context.Context, providerID uuid.UUID, limit int) ([]model.HealthEvent, error) {
+	return nil, nil
+}
+
 func (m *mockProviderStore) List(ctx context.Context, filter *store.ProviderFilter, pagination *store.Pagination) (model.ProviderList, error) {
 	return m.providers, nil
 }
 
+func (m *mockProviderStore) ListWithInstanceCounts(ctx context.Context, filter *store.ProviderFilter, pagination *store.Pagination) ([]store.ProviderWithInstanceCount, error) {
+	result := make([]store.ProviderWithInstanceCount, len(m.providers))
+	for i, p := range m.providers {
+		result[i] = store.ProviderWithInstanceCount{Provider: p}
+	}
+	return result, nil
+}
+
 func (m *mockProviderStore) Count(ctx context.Context, filter *store.ProviderFilter) (int64, error) {
 	return int64(len(m.providers)), nil
 }
@@ -84,10 +129,18 @@ func (m *mockProviderStore) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *mockProviderStore) Restore(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	return nil, store.ErrProviderNotFound
+}
+
 func (m *mockProviderStore) Update(ctx context.Context, provider model.Provider) (*model.Provider, error) {
 	return &provider, nil
 }
 
+func (m *mockProviderStore) Patch(ctx context.Context, id uuid.UUID, version *int, fields map[string]any) (*model.Provider, error) {
+	return nil, store.ErrProviderNotFound
+}
+
 func (m *mockProviderStore) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
 	for _, p := range m.providers {
 		if p.ID == id {
@@ -199,6 +252,46 @@ var _ = Describe("Monitor", func() {
 			})
 		})
 
+		Context("with many providers", func() {
+			It("checks them concurrently up to the configured limit", func() {
+				var inFlight, maxInFlight atomic.Int32
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					n := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						m := maxInFlight.Load()
+						if n <= m || maxInFlight.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{}
+				for i := range 50 {
+					mockStore.providers = append(mockStore.providers, model.Provider{
+						ID: uuid.New(), Name: fmt.Sprintf("provider-%d", i), Endpoint: server.URL, HealthStatus: model.HealthStatusReady,
+					})
+				}
+
+				cfg.Concurrency = 5
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				monitor.CheckProviders(ctx)
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(50))
+				checked := map[uuid.UUID]bool{}
+				for _, update := range mockStore.healthStatusUpdates {
+					Expect(update.ConsecutiveFailures).To(BeZero())
+					checked[update.ID] = true
+				}
+				Expect(checked).To(HaveLen(50))
+				Expect(maxInFlight.Load()).To(BeNumerically(">", 1))
+				Expect(maxInFlight.Load()).To(BeNumerically("<=", 5))
+			})
+		})
+
 		Context("with an unhealthy provider", func() {
 			It("becomes NotReady after reaching max consecutive failures", func() {
 				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -228,6 +321,213 @@ var _ = Describe("Monitor", func() {
 				Expect(update.ConsecutiveFailures).To(Equal(3))
 			})
 
+			It("makes a provider that was never checked not ready on its first failure", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}))
+				defer server.Close()
+
+				monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg)
+				result, err := monitor.CheckProvider(ctx, model.Provider{ID: uuid.New(), Name: "new-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusUnknown})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Status).To(Equal(model.HealthStatusNotReady))
+				Expect(result.ConsecutiveFailures).To(Equal(1))
+			})
+
+			It("counts the transition and the probe in the metrics", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady, ConsecutiveFailures: 2},
+					},
+				}
+
+				m := metrics.New(metrics.NewRegistry())
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithMetrics(m))
+				monitor.CheckProviders(ctx)
+
+				Expect(testutil.ToFloat64(m.HealthTransitions.WithLabelValues("ready", "not_ready"))).To(Equal(1.0))
+				var observed dto.Metric
+				Expect(m.ProviderCallDuration.WithLabelValues(metrics.CallHealthCheck).(prometheus.Histogram).Write(&observed)).To(Succeed())
+				Expect(observed.GetHistogram().GetSampleCount()).To(BeEquivalentTo(1))
+			})
+
+			It("posts a signed event to the webhook, retrying failed deliveries", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}))
+				defer server.Close()
+
+				type delivery struct {
+					body      []byte
+					signature string
+				}
+				var attempts atomic.Int32
+				deliveries := make(chan delivery, 1)
+				receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if attempts.Add(1) == 1 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					body, _ := io.ReadAll(r.Body)
+					deliveries <- delivery{body: body, signature: r.Header.Get(healthcheck.SignatureHeader)}
+				}))
+				defer receiver.Close()
+
+				provider := model.Provider{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady, ConsecutiveFailures: 2}
+				fakeClock := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+				cfg.WebhookURL = receiver.URL
+				cfg.WebhookSecret = "s3cret"
+				cfg.WebhookMaxAttempts = 3
+				cfg.WebhookRetryInterval = time.Millisecond
+				monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg, healthcheck.WithClock(fakeClock))
+
+				_, err := monitor.CheckProvider(ctx, provider)
+				Expect(err).NotTo(HaveOccurred())
+
+				var got delivery
+				Eventually(deliveries).Should(Receive(&got))
+				Expect(attempts.Load()).To(BeEquivalentTo(2))
+				Expect(got.signature).To(Equal(healthcheck.Sign([]byte("s3cret"), got.body)))
+				Expect(got.body).To(MatchJSON(fmt.Sprintf(`{
+					"provider_id": %q,
+					"provider_name": "test-provider",
+					"old_status": "ready",
+					"new_status": "not_ready",
+					"timestamp": "2025-01-01T12:00:00Z"
+				}`, provider.ID)))
+			})
+
+			It("stops without waiting for webhook retries", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}))
+				defer server.Close()
+
+				var attempts atomic.Int32
+				receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					attempts.Add(1)
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}))
+				defer receiver.Close()
+
+				cfg.WebhookURL = receiver.URL
+				cfg.WebhookMaxAttempts = 3
+				cfg.WebhookRetryInterval = time.Hour
+				monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg)
+				_, err := monitor.CheckProvider(ctx, model.Provider{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady, ConsecutiveFailures: 2})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(attempts.Load).Should(BeEquivalentTo(1))
+
+				stopped := make(chan struct{})
+				go func() {
+					monitor.Stop()
+					close(stopped)
+				}()
+
+				Eventually(stopped).Should(BeClosed())
+				Expect(attempts.Load()).To(BeEquivalentTo(1))
+			})
+
+			It("reports every stored health update to the hook", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				defer server.Close()
+
+				provider := model.Provider{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady}
+				var updated []uuid.UUID
+				monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg)
+				monitor.OnHealthUpdate(func(id uuid.UUID) { updated = append(updated, id) })
+
+				_, err := monitor.CheckProvider(ctx, provider)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(updated).To(Equal([]uuid.UUID{provider.ID}))
+			})
+
+			It("does not call the webhook when the status is unchanged", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				defer server.Close()
+
+				var calls atomic.Int32
+				receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls.Add(1)
+				}))
+				defer receiver.Close()
+
+				cfg.WebhookURL = receiver.URL
+				monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg)
+				_, err := monitor.CheckProvider(ctx, model.Provider{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady})
+				Expect(err).NotTo(HaveOccurred())
+				monitor.Stop()
+
+				Expect(calls.Load()).To(BeZero())
+			})
+
+			It("records every check in the provider's health history", func() {
+				healthy := true
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if !healthy {
+						w.WriteHeader(http.StatusServiceUnavailable)
+					}
+				}))
+				defer server.Close()
+
+				provider := model.Provider{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady}
+				mockStore := &mockProviderStore{}
+				cfg.HistorySize = 10
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+
+				_, err := monitor.CheckProvider(ctx, provider)
+				Expect(err).NotTo(HaveOccurred())
+				healthy = false
+				_, err = monitor.CheckProvider(ctx, provider)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockStore.healthEvents).To(HaveLen(2))
+				Expect(mockStore.healthEvents[0].ProviderID).To(Equal(provider.ID))
+				Expect(mockStore.healthEvents[0].Status).To(Equal(model.HealthStatusReady))
+				Expect(mockStore.healthEvents[0].HTTPStatus).To(Equal(http.StatusOK))
+				Expect(mockStore.healthEvents[0].Error).To(BeEmpty())
+				Expect(mockStore.healthEvents[1].HTTPStatus).To(Equal(http.StatusServiceUnavailable))
+				Expect(mockStore.healthEvents[1].Error).To(Equal("status code 503"))
+			})
+
+			It("records only status changes of providers with health checks disabled", func() {
+				mockStore := &mockProviderStore{}
+				cfg.HistorySize = 10
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				provider := model.Provider{ID: uuid.New(), Name: "static", Endpoint: "http://static.invalid",
+					HealthStatus: model.HealthStatusNotReady, HealthCheckDisabled: true}
+
+				_, err := monitor.CheckProvider(ctx, provider)
+				Expect(err).NotTo(HaveOccurred())
+				provider.HealthStatus = model.HealthStatusReady
+				for range 3 {
+					_, err = monitor.CheckProvider(ctx, provider)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(mockStore.healthEvents).To(HaveLen(1))
+				Expect(mockStore.healthEvents[0].Status).To(Equal(model.HealthStatusReady))
+			})
+
+			It("records no history when it is disabled", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{}
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				_, err := monitor.CheckProvider(ctx, model.Provider{ID: uuid.New(), Name: "test-provider", Endpoint: server.URL})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockStore.healthEvents).To(BeEmpty())
+			})
+
 			It("stays Ready until reaching max consecutive failures", func() {
 				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusInternalServerError)
@@ -286,5 +586,530 @@ var _ = Describe("Monitor", func() {
 				Expect(update.ConsecutiveFailures).To(Equal(0))
 			})
 		})
+
+		Context("when the context is cancelled mid-cycle", func() {
+			It("records no failure for the interrupted provider and skips the rest", func() {
+				started := make(chan struct{})
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					close(started)
+					<-r.Context().Done()
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "interrupted", Endpoint: server.URL, HealthStatus: model.HealthStatusReady},
+						{ID: uuid.New(), Name: "skipped", Endpoint: server.URL, HealthStatus: model.HealthStatusReady},
+					},
+				}
+
+				cycleCtx, cancel := context.WithCancel(ctx)
+				go func() {
+					<-started
+					cancel()
+				}()
+
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				monitor.CheckProviders(cycleCtx)
+
+				Expect(mockStore.healthStatusUpdates).To(BeEmpty())
+			})
+
+			It("reports the cancellation from CheckProvider", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{}
+				cancelled, cancel := context.WithCancel(ctx)
+				cancel()
+
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				_, err := monitor.CheckProvider(cancelled, model.Provider{ID: uuid.New(), Name: "p", Endpoint: server.URL})
+
+				Expect(err).To(MatchError(healthcheck.ErrCheckCancelled))
+				Expect(mockStore.healthStatusUpdates).To(BeEmpty())
+			})
+
+			It("still counts a client timeout as a failure", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					<-r.Context().Done()
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "slow", Endpoint: server.URL, HealthStatus: model.HealthStatusReady},
+					},
+				}
+
+				cfg.Timeout = 50 * time.Millisecond
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				monitor.CheckProviders(ctx)
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+				Expect(mockStore.healthStatusUpdates[0].ConsecutiveFailures).To(Equal(1))
+			})
+		})
+
+		Context("with a provider timeout override", func() {
+			It("uses the provider's timeout instead of the global one", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(200 * time.Millisecond)
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				patient := model.Provider{ID: uuid.New(), Name: "patient", Endpoint: server.URL, HealthStatus: model.HealthStatusReady, TimeoutSeconds: 1}
+				hasty := model.Provider{ID: uuid.New(), Name: "hasty", Endpoint: server.URL, HealthStatus: model.HealthStatusReady}
+				mockStore := &mockProviderStore{providers: model.ProviderList{patient, hasty}}
+
+				cfg.Timeout = 50 * time.Millisecond
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				monitor.CheckProviders(ctx)
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(2))
+				Expect(mockStore.healthStatusUpdates[0].ID).To(Equal(patient.ID))
+				Expect(mockStore.healthStatusUpdates[0].ConsecutiveFailures).To(BeZero())
+				Expect(mockStore.healthStatusUpdates[1].ConsecutiveFailures).To(Equal(1))
+			})
+
+			It("clamps the override", func() {
+				Expect(healthcheck.ProviderTimeout(30)).To(Equal(30 * time.Second))
+				Expect(healthcheck.ProviderTimeout(100000)).To(Equal(healthcheck.MaxProviderTimeout))
+			})
+		})
+
+		Context("with a custom health check", func() {
+			var (
+				paths   chan string
+				server  *httptest.Server
+				healthy = func(p model.Provider) bool {
+					mockStore := &mockProviderStore{providers: model.ProviderList{p}}
+					monitor = healthcheck.NewMonitor(mockStore, cfg)
+					monitor.CheckProviders(ctx)
+					Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+					return mockStore.healthStatusUpdates[0].ConsecutiveFailures == 0
+				}
+			)
+
+			BeforeEach(func() {
+				paths = make(chan string, 1)
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					select {
+					case paths <- r.URL.Path:
+					default:
+					}
+					w.WriteHeader(http.StatusNoContent)
+				}))
+				DeferCleanup(server.Close)
+			})
+
+			It("checks the provider's health path", func() {
+				path := "/readyz"
+				Expect(healthy(model.Provider{ID: uuid.New(), Name: "readyz", Endpoint: server.URL + "/api", HealthPath: &path})).To(BeTrue())
+				Expect(<-paths).To(Equal("/api/readyz"))
+			})
+
+			It("checks the endpoint itself for an empty path", func() {
+				path := ""
+				Expect(healthy(model.Provider{ID: uuid.New(), Name: "root", Endpoint: server.URL + "/api", HealthPath: &path})).To(BeTrue())
+				Expect(<-paths).To(Equal("/api"))
+			})
+
+			It("defaults to /health", func() {
+				Expect(healthy(model.Provider{ID: uuid.New(), Name: "default", Endpoint: server.URL})).To(BeTrue())
+				Expect(<-paths).To(Equal("/health"))
+			})
+
+			It("accepts a 204 unless other statuses are expected", func() {
+				Expect(healthy(model.Provider{ID: uuid.New(), Name: "no-content", Endpoint: server.URL,
+					HealthExpectedStatuses: []int{http.StatusOK, http.StatusNoContent}})).To(BeTrue())
+				Expect(healthy(model.Provider{ID: uuid.New(), Name: "ok-only", Endpoint: server.URL,
+					HealthExpectedStatuses: []int{http.StatusOK}})).To(BeFalse())
+			})
+
+			It("does not contact a provider with health checks disabled", func() {
+				Expect(healthy(model.Provider{ID: uuid.New(), Name: "static", Endpoint: server.URL,
+					HealthStatus: model.HealthStatusNotReady, HealthCheckDisabled: true})).To(BeTrue())
+				Expect(paths).To(BeEmpty())
+			})
+		})
+
+		Context("with a host allowlist", func() {
+			It("does not contact a provider whose host is not listed", func() {
+				var requests atomic.Int32
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests.Add(1)
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{
+							ID:           uuid.New(),
+							Name:         "test-provider",
+							Endpoint:     server.URL,
+							HealthStatus: model.HealthStatusReady,
+						},
+					},
+				}
+
+				allowlist := netpolicy.NewHostAllowlist([]string{"providers.example.com"})
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithHostAllowlist(allowlist))
+				monitor.CheckProviders(ctx)
+
+				Expect(requests.Load()).To(BeZero())
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+				Expect(mockStore.healthStatusUpdates[0].ConsecutiveFailures).To(Equal(1))
+			})
+		})
+
+		Context("with an https endpoint", func() {
+			var (
+				server    *httptest.Server
+				transport http.RoundTripper
+				provider  model.Provider
+				mockStore *mockProviderStore
+			)
+
+			BeforeEach(func() {
+				server, transport = tlsServerWithCert(time.Now().Add(48 * time.Hour))
+				DeferCleanup(server.Close)
+				provider = model.Provider{ID: uuid.New(), Name: "tls-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady}
+				mockStore = &mockProviderStore{}
+				cfg.HistorySize = 10
+				cfg.CertExpiryWarning = 7 * 24 * time.Hour
+			})
+
+			It("records the certificate expiry and warns when it is close", func() {
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithTransport(transport))
+
+				result, err := monitor.CheckProvider(ctx, provider)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Err).NotTo(HaveOccurred())
+				Expect(result.Status).To(Equal(model.HealthStatusReady))
+				Expect(result.Warning).To(HavePrefix("certificate expires at "))
+				Expect(mockStore.healthEvents).To(HaveLen(1))
+				Expect(mockStore.healthEvents[0].CertNotAfter).NotTo(BeNil())
+				Expect(*mockStore.healthEvents[0].CertNotAfter).To(BeTemporally("~", time.Now().Add(48*time.Hour), time.Minute))
+				Expect(mockStore.healthEvents[0].Warning).To(Equal(result.Warning))
+			})
+
+			It("does not warn outside the window", func() {
+				cfg.CertExpiryWarning = 24 * time.Hour
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithTransport(transport))
+
+				result, err := monitor.CheckProvider(ctx, provider)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.CertNotAfter).NotTo(BeNil())
+				Expect(result.Warning).To(BeEmpty())
+			})
+
+			It("counts the warning as a failure when configured to", func() {
+				cfg.CertExpiryFails = true
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithTransport(transport))
+
+				result, err := monitor.CheckProvider(ctx, provider)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Err).To(MatchError(HavePrefix("certificate expires at ")))
+				Expect(result.ConsecutiveFailures).To(Equal(1))
+			})
+		})
+
+		Context("with provider credentials", func() {
+			It("sends them with the health check", func() {
+				received := make(chan http.Header, 2)
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					received <- r.Header.Clone()
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "bearer-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady,
+							AuthType: model.AuthTypeBearer, AuthToken: "bearer-token"},
+					},
+				}
+				monitor = healthcheck.NewMonitor(mockStore, cfg)
+				monitor.CheckProviders(ctx)
+				Expect((<-received).Get("Authorization")).To(Equal("Bearer bearer-token"))
+
+				mockStore.providers[0].AuthType = model.AuthTypeHeader
+				mockStore.providers[0].AuthHeader = "X-Provider-Key"
+				mockStore.providers[0].NextHealthCheck = nil
+				monitor.CheckProviders(ctx)
+				header := <-received
+				Expect(header.Get("X-Provider-Key")).To(Equal("bearer-token"))
+				Expect(header.Get("Authorization")).To(BeEmpty())
+			})
+		})
+
+		Context("with debug logging", func() {
+			It("logs calls only for providers that enable it, with credentials redacted", func() {
+				var logs bytes.Buffer
+
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+				endpoint := strings.Replace(server.URL, "http://", "http://admin:s3cret@", 1)
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "debugged-provider", Endpoint: endpoint, HealthStatus: model.HealthStatusReady, DebugLogging: true},
+						{ID: uuid.New(), Name: "quiet-provider", Endpoint: endpoint, HealthStatus: model.HealthStatusReady},
+					},
+				}
+
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithLogger(logging.New(&logs, slog.LevelInfo)))
+				monitor.CheckProviders(ctx)
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(2))
+				Expect(logs.String()).To(ContainSubstring(`msg="Provider request" provider_name=debugged-provider method=GET`))
+				Expect(logs.String()).To(ContainSubstring(`msg="Provider response" provider_name=debugged-provider method=GET`))
+				Expect(logs.String()).To(ContainSubstring("status=200"))
+				Expect(logs.String()).To(ContainSubstring("Authorization: REDACTED"))
+				Expect(logs.String()).NotTo(ContainSubstring("s3cret"))
+				Expect(logs.String()).NotTo(ContainSubstring("quiet-provider"))
+			})
+
+			It("redacts the provider's custom credential header", func() {
+				var logs bytes.Buffer
+
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "debugged-provider", Endpoint: server.URL, HealthStatus: model.HealthStatusReady, DebugLogging: true,
+							AuthType: model.AuthTypeHeader, AuthHeader: "X-Provider-Key", AuthToken: "s3cret"},
+					},
+				}
+
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithLogger(logging.New(&logs, slog.LevelInfo)))
+				monitor.CheckProviders(ctx)
+
+				Expect(logs.String()).To(ContainSubstring("X-Provider-Key: REDACTED"))
+				Expect(logs.String()).NotTo(ContainSubstring("s3cret"))
+			})
+		})
+
+		Context("with a logger", func() {
+			var server *httptest.Server
+
+			BeforeEach(func() {
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}))
+				DeferCleanup(server.Close)
+			})
+
+			checkFailingProvider := func(level slog.Level) string {
+				var logs bytes.Buffer
+				mockStore := &mockProviderStore{
+					providers: model.ProviderList{
+						{ID: uuid.New(), Name: "flaky", Endpoint: server.URL, HealthStatus: model.HealthStatusReady},
+					},
+				}
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithLogger(logging.New(&logs, level)))
+				monitor.CheckProviders(ctx)
+				return logs.String()
+			}
+
+			It("keeps individual check failures out of info logs", func() {
+				Expect(checkFailingProvider(slog.LevelInfo)).To(BeEmpty())
+			})
+
+			It("logs failures and every provider call at debug level", func() {
+				logs := checkFailingProvider(slog.LevelDebug)
+
+				Expect(logs).To(ContainSubstring(`msg="Health check failed" provider_name=flaky consecutive_failures=1`))
+				Expect(logs).To(ContainSubstring(`msg="Provider response" provider_name=flaky method=GET`))
+			})
+		})
+	})
+
+	Describe("with a fake clock", func() {
+		var (
+			fakeClock *clock.Fake
+			start     time.Time
+			mockStore *mockProviderStore
+			failing   *httptest.Server
+		)
+
+		BeforeEach(func() {
+			start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			fakeClock = clock.NewFake(start)
+			failing = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			mockStore = &mockProviderStore{
+				providers: model.ProviderList{
+					{
+						ID:                  uuid.New(),
+						Name:                "test-provider",
+						Endpoint:            failing.URL,
+						HealthStatus:        model.HealthStatusNotReady,
+						ConsecutiveFailures: 4,
+					},
+				},
+			}
+			monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithClock(fakeClock))
+		})
+
+		AfterEach(func() {
+			failing.Close()
+		})
+
+		It("schedules the backoff from the clock's time", func() {
+			monitor.CheckProviders(ctx)
+
+			Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+			// 5 failures is 2 past the threshold: 10s * 2^2
+			Expect(mockStore.healthStatusUpdates[0].NextCheck).To(Equal(start.Add(40 * time.Second)))
+		})
+
+		It("only checks providers once their next check is due", func() {
+			monitor.CheckProviders(ctx)
+			next := mockStore.healthStatusUpdates[0].NextCheck
+			mockStore.providers[0].NextHealthCheck = &next
+			mockStore.providers[0].ConsecutiveFailures = 5
+
+			fakeClock.Advance(39 * time.Second)
+			monitor.CheckProviders(ctx)
+			Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+
+			fakeClock.Advance(time.Second)
+			monitor.CheckProviders(ctx)
+			Expect(mockStore.healthStatusUpdates).To(HaveLen(2))
+			Expect(mockStore.healthStatusUpdates[1].NextCheck).To(Equal(start.Add(40*time.Second + 80*time.Second)))
+		})
+
+		Context("with a maintenance window", func() {
+			BeforeEach(func() {
+				windowStart, windowEnd := start.Add(-time.Minute), start.Add(time.Hour)
+				mockStore.providers[0].HealthStatus = model.HealthStatusReady
+				mockStore.providers[0].ConsecutiveFailures = 2
+				mockStore.providers[0].MaintenanceStart = &windowStart
+				mockStore.providers[0].MaintenanceEnd = &windowEnd
+			})
+
+			It("marks a failing provider as in maintenance without counting the failure", func() {
+				monitor.CheckProviders(ctx)
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+				update := mockStore.healthStatusUpdates[0]
+				Expect(update.Status).To(Equal(model.HealthStatusMaintenance))
+				Expect(update.ConsecutiveFailures).To(Equal(2))
+				Expect(update.NextCheck).To(Equal(start.Add(cfg.Interval)))
+			})
+
+			It("does not report entering or leaving the window as a transition", func() {
+				var calls atomic.Int32
+				receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls.Add(1)
+				}))
+				defer receiver.Close()
+				cfg.WebhookURL = receiver.URL
+				m := metrics.New(metrics.NewRegistry())
+				monitor = healthcheck.NewMonitor(mockStore, cfg, healthcheck.WithClock(fakeClock), healthcheck.WithMetrics(m))
+
+				monitor.CheckProviders(ctx)
+				mockStore.providers[0].HealthStatus = model.HealthStatusMaintenance
+				fakeClock.Advance(time.Hour)
+				monitor.CheckProviders(ctx)
+				monitor.Stop()
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(2))
+				Expect(mockStore.healthStatusUpdates[0].Status).To(Equal(model.HealthStatusMaintenance))
+				Expect(mockStore.healthStatusUpdates[1].Status).To(Equal(model.HealthStatusNotReady))
+				Expect(testutil.ToFloat64(m.HealthTransitions.WithLabelValues("ready", "maintenance"))).To(BeZero())
+				Expect(testutil.ToFloat64(m.HealthTransitions.WithLabelValues("maintenance", "not_ready"))).To(BeZero())
+				Expect(calls.Load()).To(BeZero())
+			})
+
+			It("counts failures again once the window is over", func() {
+				mockStore.providers[0].HealthStatus = model.HealthStatusMaintenance
+				fakeClock.Advance(time.Hour)
+
+				monitor.CheckProviders(ctx)
+
+				Expect(mockStore.healthStatusUpdates).To(HaveLen(1))
+				update := mockStore.healthStatusUpdates[0]
+				Expect(update.Status).To(Equal(model.HealthStatusNotReady))
+				Expect(update.ConsecutiveFailures).To(Equal(3))
+			})
+		})
+
+		It("reports a stale monitor without waiting", func() {
+			monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg, healthcheck.WithClock(fakeClock))
+			monitor.Start(ctx)
+			defer monitor.Stop()
+			Expect(monitor.CheckHealth(ctx)).To(Succeed())
+
+			// The ticker runs on real time, so only the initial cycle completes. Once it
+			// has, moving the clock past three intervals makes the monitor stale.
+			Eventually(func() error {
+				fakeClock.Advance(4 * cfg.Interval)
+				return monitor.CheckHealth(ctx)
+			}).Should(MatchError(ContainSubstring("last health check cycle")))
+		})
+	})
+
+	Describe("CheckHealth", func() {
+		It("reports an error when the monitor is not running", func() {
+			monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg)
+
+			Expect(monitor.CheckHealth(ctx)).To(MatchError(ContainSubstring("not running")))
+		})
+
+		It("is healthy while running and unhealthy after Stop", func() {
+			monitor = healthcheck.NewMonitor(&mockProviderStore{}, cfg)
+			monitor.Start(ctx)
+
+			Eventually(func() error { return monitor.CheckHealth(ctx) }).Should(Succeed())
+
+			monitor.Stop()
+			Expect(monitor.CheckHealth(ctx)).To(HaveOccurred())
+		})
 	})
 })
+
+// tlsServerWithCert starts an https server with a self-signed certificate that
+// expires at notAfter, and returns it with a transport that trusts the certificate.
+func tlsServerWithCert(notAfter time.Time) (*httptest.Server, http.RoundTripper) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	server.StartTLS()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	return server, &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+}
@@ -0,0 +1,126 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a webhook request body, keyed with
+// the webhook secret and prefixed with "sha256=".
+const SignatureHeader = "X-SPM-Signature"
+
+// HealthTransitionEvent is the body POSTed to the webhook when a provider's health
+// status changes.
+type HealthTransitionEvent struct {
+	ProviderID   uuid.UUID          `json:"provider_id"`
+	ProviderName string             `json:"provider_name"`
+	OldStatus    model.HealthStatus `json:"old_status"`
+	NewStatus    model.HealthStatus `json:"new_status"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+// webhook delivers health transition events in the background, so a slow or
+// unreachable receiver never holds up a health check cycle.
+type webhook struct {
+	url           string
+	secret        []byte
+	client        *http.Client
+	maxAttempts   int
+	retryInterval time.Duration
+	logger        *slog.Logger
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+}
+
+// notify starts delivering the event and returns right away.
+func (w *webhook) notify(event HealthTransitionEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		w.logger.Error("Error encoding health transition event", "provider_name", event.ProviderName, "error", err)
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.deliver(event, body)
+	}()
+}
+
+// stop cancels pending retries and waits for the deliveries in progress.
+func (w *webhook) stop() {
+	close(w.stopCh)
+	w.wg.Wait()
+}
+
+// deliver POSTs the body until the receiver accepts it with a 2xx response, waiting
+// twice as long after each failed attempt, and logs the event as lost when every
+// attempt fails or the webhook is stopped before the next one.
+func (w *webhook) deliver(event HealthTransitionEvent, body []byte) {
+	wait := w.retryInterval
+	var err error
+	for attempt := 1; attempt <= w.maxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-w.stopCh:
+				timer.Stop()
+				w.dropped(event, attempt-1, err)
+				return
+			}
+			wait *= 2
+		}
+		if err = w.post(body); err == nil {
+			return
+		}
+		w.logger.Debug("Webhook delivery failed", "provider_name", event.ProviderName, "attempt", attempt, "error", err)
+	}
+	w.dropped(event, w.maxAttempts, err)
+}
+
+func (w *webhook) dropped(event HealthTransitionEvent, attempts int, err error) {
+	w.logger.Error("Error delivering health transition event", "provider_name", event.ProviderName,
+		"from", event.OldStatus, "to", event.NewStatus, "attempts", attempts, "error", err)
+}
+
+func (w *webhook) post(body []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body, so receivers can verify it with
+// hmac.Equal.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,44 @@
+package logging_test
+
+import (
+	"bytes"
+	"log/slog"
+
+	"github.com/dcm-project/service-provider-manager/internal/logging"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseLevel", func() {
+	DescribeTable("parses level names",
+		func(name string, expected slog.Level) {
+			level, err := logging.ParseLevel(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(level).To(Equal(expected))
+		},
+		Entry("debug", "debug", slog.LevelDebug),
+		Entry("info", "info", slog.LevelInfo),
+		Entry("empty as info", "", slog.LevelInfo),
+		Entry("warn", "WARN", slog.LevelWarn),
+		Entry("warning", "warning", slog.LevelWarn),
+		Entry("error", "error", slog.LevelError),
+	)
+
+	It("rejects an unknown level", func() {
+		_, err := logging.ParseLevel("verbose")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("New", func() {
+	It("drops records below the level", func() {
+		var buf bytes.Buffer
+		logger := logging.New(&buf, slog.LevelInfo)
+
+		logger.Debug("hidden")
+		logger.Info("shown", "provider_name", "kubevirt")
+
+		Expect(buf.String()).NotTo(ContainSubstring("hidden"))
+		Expect(buf.String()).To(ContainSubstring("msg=shown provider_name=kubevirt"))
+	})
+})
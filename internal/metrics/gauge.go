@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// GaugeFunc is a gauge with a single label whose values are collected on every
+// scrape.
+type GaugeFunc struct {
+	desc    *prometheus.Desc
+	collect func(ctx context.Context) (map[string]float64, error)
+}
+
+// NewGaugeFunc creates a gauge whose values, keyed by label value, are returned by
+// collect when the metrics are scraped. It must be registered to be served.
+func NewGaugeFunc(name, help, label string, collect func(ctx context.Context) (map[string]float64, error)) *GaugeFunc {
+	return &GaugeFunc{
+		desc:    prometheus.NewDesc(name, help, []string{label}, nil),
+		collect: collect,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (g *GaugeFunc) Describe(ch chan<- *prometheus.Desc) {
+	ch <- g.desc
+}
+
+// Collect implements prometheus.Collector.
+func (g *GaugeFunc) Collect(ch chan<- prometheus.Metric) {
+	values, err := g.collect(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(g.desc, err)
+		return
+	}
+	for value, v := range values {
+		ch <- prometheus.MustNewConstMetric(g.desc, prometheus.GaugeValue, v, value)
+	}
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// The kinds of provider call observed by ProviderCallDuration.
+const (
+	CallHealthCheck    = "health_check"
+	CallInstanceStatus = "instance_status"
+)
+
+// Metrics are the service's instruments, all registered in Registry. A nil *Metrics
+// disables instrumentation.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	// ProviderOperations counts provider create, update, delete and restore calls by
+	// result, "ok" or the service error code.
+	ProviderOperations *prometheus.CounterVec
+	// ProviderCallDuration observes the latency of calls to providers by kind of
+	// call. It is not labelled by provider, whose names are unbounded.
+	ProviderCallDuration *prometheus.HistogramVec
+	// HealthTransitions counts provider health status changes.
+	HealthTransitions *prometheus.CounterVec
+}
+
+// NewRegistry creates a registry with the standard process and Go runtime metrics.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewGoCollector(),
+	)
+	return registry
+}
+
+// New creates the service's metrics and registers them in registry.
+func New(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		Registry: registry,
+		ProviderOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "spm_provider_operations_total",
+			Help: "Provider create, update, delete and restore operations by result.",
+		}, []string{"operation", "result"}),
+		ProviderCallDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "spm_provider_call_duration_seconds",
+			Help:    "Latency of calls to providers by kind of call.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"call"}),
+		HealthTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "spm_provider_health_transitions_total",
+			Help: "Provider health status changes.",
+		}, []string{"from", "to"}),
+	}
+	registry.MustRegister(m.ProviderOperations, m.ProviderCallDuration, m.HealthTransitions)
+	return m
+}
+
+// Handler serves the metrics in Registry. Metrics that fail to collect are logged
+// and left out; the others are still served.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
+		ErrorHandling: promhttp.ContinueOnError,
+		Registry:      m.Registry,
+	})
+}
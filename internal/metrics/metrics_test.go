@@ -0,0 +1,76 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = Describe("Metrics", func() {
+	var m *metrics.Metrics
+
+	BeforeEach(func() {
+		m = metrics.New(metrics.NewRegistry())
+	})
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		return rec.Body.String()
+	}
+
+	It("serves the service's instruments", func() {
+		m.ProviderOperations.WithLabelValues("create", "ok").Inc()
+		m.ProviderCallDuration.WithLabelValues(metrics.CallHealthCheck).Observe(0.05)
+		m.HealthTransitions.WithLabelValues("ready", "not_ready").Inc()
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`spm_provider_operations_total{operation="create",result="ok"} 1`))
+		Expect(body).To(ContainSubstring(`spm_provider_call_duration_seconds_count{call="health_check"} 1`))
+		Expect(body).To(ContainSubstring(`spm_provider_health_transitions_total{from="ready",to="not_ready"} 1`))
+	})
+
+	It("serves the standard process and Go runtime metrics", func() {
+		body := scrape()
+		Expect(body).To(ContainSubstring("go_goroutines "))
+		Expect(body).To(ContainSubstring("process_start_time_seconds "))
+	})
+
+	It("rejects an observation with the wrong number of labels", func() {
+		Expect(func() {
+			m.ProviderCallDuration.WithLabelValues(metrics.CallHealthCheck, "test-provider")
+		}).To(Panic())
+	})
+
+	Describe("GaugeFunc", func() {
+		It("collects values on scrape and skips gauges that fail", func() {
+			m.Registry.MustRegister(
+				metrics.NewGaugeFunc("spm_providers", "Providers.", "health_status", func(context.Context) (map[string]float64, error) {
+					return map[string]float64{"ready": 2, "not_ready": 1}, nil
+				}),
+				metrics.NewGaugeFunc("spm_broken", "Broken.", "label", func(context.Context) (map[string]float64, error) {
+					return nil, errors.New("database is down")
+				}),
+			)
+
+			body := scrape()
+			Expect(body).To(ContainSubstring(`spm_providers{health_status="not_ready"} 1`))
+			Expect(body).To(ContainSubstring(`spm_providers{health_status="ready"} 2`))
+			Expect(body).NotTo(ContainSubstring("spm_broken"))
+		})
+
+		It("cannot be registered twice", func() {
+			collect := func(context.Context) (map[string]float64, error) { return nil, nil }
+			Expect(m.Registry.Register(metrics.NewGaugeFunc("spm_providers", "Providers.", "health_status", collect))).To(Succeed())
+			err := m.Registry.Register(metrics.NewGaugeFunc("spm_providers", "Providers.", "health_status", collect))
+			Expect(errors.As(err, &prometheus.AlreadyRegisteredError{})).To(BeTrue())
+		})
+	})
+})
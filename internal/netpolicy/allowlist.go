@@ -0,0 +1,64 @@
+// Package netpolicy restricts which hosts the manager may contact and how it
+// verifies them.
+package netpolicy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrHostNotAllowed is returned for endpoints whose host is not on the allowlist.
+var ErrHostNotAllowed = errors.New("host is not on the provider allowlist")
+
+// HostAllowlist is a set of permitted provider hostnames. An entry matches the host
+// itself and any of its subdomains. A nil or empty allowlist permits every host.
+type HostAllowlist struct {
+	entries []string
+}
+
+// NewHostAllowlist creates an allowlist from hostnames or domains. Entries are
+// compared case-insensitively; blank entries are ignored.
+func NewHostAllowlist(entries []string) *HostAllowlist {
+	a := &HostAllowlist{}
+	for _, entry := range entries {
+		entry = strings.Trim(strings.ToLower(strings.TrimSpace(entry)), ".")
+		if entry != "" {
+			a.entries = append(a.entries, entry)
+		}
+	}
+	return a
+}
+
+// Allows reports whether the host is permitted.
+func (a *HostAllowlist) Allows(host string) bool {
+	if a == nil || len(a.entries) == 0 {
+		return true
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, entry := range a.entries {
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckEndpoint returns an error wrapping ErrHostNotAllowed unless the endpoint URL's
+// host is permitted.
+func (a *HostAllowlist) CheckEndpoint(endpoint string) error {
+	if a == nil || len(a.entries) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("parsing endpoint %s: %w", endpoint, err)
+	}
+	if !a.Allows(u.Hostname()) {
+		return fmt.Errorf("%s: %w", u.Hostname(), ErrHostNotAllowed)
+	}
+	return nil
+}
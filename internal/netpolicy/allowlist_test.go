@@ -0,0 +1,32 @@
+package netpolicy_test
+
+import (
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostAllowlist", func() {
+	It("allows every host when empty", func() {
+		allowlist := netpolicy.NewHostAllowlist(nil)
+
+		Expect(allowlist.Allows("anything.example.org")).To(BeTrue())
+		Expect(allowlist.CheckEndpoint("https://anything.example.org/api")).To(Succeed())
+	})
+
+	It("allows listed hosts and their subdomains", func() {
+		allowlist := netpolicy.NewHostAllowlist([]string{" Providers.Example.com ", ""})
+
+		Expect(allowlist.Allows("providers.example.com")).To(BeTrue())
+		Expect(allowlist.Allows("kubevirt.providers.example.com")).To(BeTrue())
+		Expect(allowlist.CheckEndpoint("https://kubevirt.providers.example.com:8443/api")).To(Succeed())
+	})
+
+	It("rejects hosts that are not listed", func() {
+		allowlist := netpolicy.NewHostAllowlist([]string{"providers.example.com"})
+
+		Expect(allowlist.Allows("example.com")).To(BeFalse())
+		Expect(allowlist.Allows("evilproviders.example.com")).To(BeFalse())
+		Expect(allowlist.CheckEndpoint("https://attacker.example.org/api")).To(MatchError(netpolicy.ErrHostNotAllowed))
+	})
+})
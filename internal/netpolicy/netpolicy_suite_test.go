@@ -0,0 +1,13 @@
+package netpolicy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNetpolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Netpolicy Suite")
+}
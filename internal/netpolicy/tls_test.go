@@ -0,0 +1,62 @@
+package netpolicy_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProviderTransport", func() {
+	var server *httptest.Server
+
+	get := func(transport http.RoundTripper) error {
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		DeferCleanup(server.Close)
+	})
+
+	It("rejects a self-signed certificate by default", func() {
+		transport, err := netpolicy.ProviderTransport("", false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(get(transport)).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("trusts certificates signed by the CA bundle", func() {
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		Expect(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)).To(Succeed())
+
+		transport, err := netpolicy.ProviderTransport(caFile, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(get(transport)).To(Succeed())
+	})
+
+	It("skips verification when asked to", func() {
+		transport, err := netpolicy.ProviderTransport("", true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(get(transport)).To(Succeed())
+	})
+
+	It("fails on a bundle without certificates", func() {
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		Expect(os.WriteFile(caFile, []byte("not a certificate"), 0o600)).To(Succeed())
+
+		_, err := netpolicy.ProviderTransport(caFile, false)
+		Expect(err).To(MatchError(ContainSubstring("no certificates found")))
+	})
+})
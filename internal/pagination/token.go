@@ -0,0 +1,61 @@
+// Package pagination encodes and decodes the opaque page tokens returned by list
+// endpoints. Decoding is strict: a token that was not produced by this package, or
+// was altered, is always an error, never a silent restart from the first page.
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidToken is returned for page tokens that cannot be decoded.
+var ErrInvalidToken = errors.New("invalid page token")
+
+// EncodeOffset returns the token for the page starting at offset.
+func EncodeOffset(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// DecodeOffset returns the offset encoded in token. Returns ErrInvalidToken unless
+// token encodes an offset that is not negative.
+func DecodeOffset(token string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: not an offset", ErrInvalidToken)
+	}
+	return offset, nil
+}
+
+// EncodeCursor returns the token for a keyset cursor, which must marshal to a JSON
+// object.
+func EncodeCursor(cursor any) string {
+	encoded, _ := json.Marshal(cursor)
+	return base64.StdEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor decodes the cursor encoded in token into cursor. Returns
+// ErrInvalidToken when token does not encode a JSON object or has fields that do
+// not belong to cursor.
+func DecodeCursor(token string, cursor any) error {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cursor); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("%w: trailing data", ErrInvalidToken)
+	}
+	return nil
+}
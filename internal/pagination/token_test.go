@@ -0,0 +1,59 @@
+package pagination_test
+
+import (
+	"encoding/base64"
+
+	"github.com/dcm-project/service-provider-manager/internal/pagination"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type cursor struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+func token(raw string) string {
+	return base64.StdEncoding.EncodeToString([]byte(raw))
+}
+
+var _ = Describe("Offset tokens", func() {
+	It("round-trip an offset", func() {
+		offset, err := pagination.DecodeOffset(pagination.EncodeOffset(40))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offset).To(Equal(40))
+	})
+
+	DescribeTable("reject tampered tokens",
+		func(tok string) {
+			_, err := pagination.DecodeOffset(tok)
+			Expect(err).To(MatchError(pagination.ErrInvalidToken))
+		},
+		Entry("not base64", "not a token!"),
+		Entry("negative offset", token("-1")),
+		Entry("not a number", token("forty")),
+		Entry("empty", ""),
+	)
+})
+
+var _ = Describe("Cursor tokens", func() {
+	It("round-trip a cursor", func() {
+		var got cursor
+		Expect(pagination.DecodeCursor(pagination.EncodeCursor(cursor{Name: "a", ID: "1"}), &got)).To(Succeed())
+
+		Expect(got).To(Equal(cursor{Name: "a", ID: "1"}))
+	})
+
+	DescribeTable("reject tampered tokens",
+		func(tok string) {
+			var got cursor
+			Expect(pagination.DecodeCursor(tok, &got)).To(MatchError(pagination.ErrInvalidToken))
+		},
+		Entry("not base64", "%%%"),
+		Entry("truncated JSON", token(`{"name":"a"`)),
+		Entry("unknown field", token(`{"name":"a","offset":5}`)),
+		Entry("trailing data", token(`{"name":"a"}{"name":"b"}`)),
+		Entry("an offset", token("5")),
+	)
+})
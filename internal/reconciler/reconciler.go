@@ -0,0 +1,218 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/internal/config"
+	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	rmstore "github.com/dcm-project/service-provider-manager/internal/store/resource_manager"
+)
+
+// Reconciler periodically polls providers for the status of instances that have not
+// reached a terminal status and stores what they report.
+type Reconciler struct {
+	instances  rmstore.ServiceTypeInstance
+	providers  store.Provider
+	httpClient *http.Client
+	interval   time.Duration
+	terminal   []string
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+	logger     *slog.Logger
+	metrics    *metrics.Metrics
+	allowlist  *netpolicy.HostAllowlist
+}
+
+// Option configures optional Reconciler behavior.
+type Option func(*Reconciler)
+
+// WithLogger sets the logger. Polling errors are logged at debug level, status
+// changes at info level, and the calls to providers with debug logging as the
+// health monitor logs them.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Reconciler) {
+		r.logger = logger
+	}
+}
+
+// WithMetrics records the latency of status requests in m.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(r *Reconciler) {
+		r.metrics = m
+	}
+}
+
+// WithHostAllowlist makes the reconciler refuse to poll providers whose endpoint
+// host is not on the allowlist.
+func WithHostAllowlist(allowlist *netpolicy.HostAllowlist) Option {
+	return func(r *Reconciler) {
+		r.allowlist = allowlist
+	}
+}
+
+// WithTransport sets the transport used to reach providers, for example one that
+// trusts a private CA.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Reconciler) {
+		r.httpClient.Transport = transport
+	}
+}
+
+// NewReconciler creates a new instance status reconciler
+func NewReconciler(instances rmstore.ServiceTypeInstance, providers store.Provider, config *config.ReconcilerConfig, opts ...Option) *Reconciler {
+	r := &Reconciler{
+		instances: instances,
+		providers: providers,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+		},
+		interval: config.Interval,
+		terminal: config.TerminalStatuses,
+		stopCh:   make(chan struct{}),
+		logger:   slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Start begins the reconciliation loop
+func (r *Reconciler) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go r.run(ctx)
+}
+
+// Stop gracefully stops the reconciler
+func (r *Reconciler) Stop() {
+	close(r.stopCh)
+	r.wg.Wait()
+}
+
+func (r *Reconciler) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	r.Reconcile(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.Reconcile(ctx)
+		}
+	}
+}
+
+// Reconcile polls the provider of every instance that is not in a terminal status.
+// Instances of providers that are not ready, or not checked yet, are skipped until
+// the provider is ready, so a failing provider is not polled for each of its
+// instances.
+func (r *Reconciler) Reconcile(ctx context.Context) {
+	instances, err := r.instances.ListNotInStatus(ctx, r.terminal)
+	if err != nil {
+		r.logger.Error("Error listing instances for reconciliation", "error", err)
+		return
+	}
+
+	providers := map[string]*model.Provider{}
+	for i, instance := range instances {
+		if ctx.Err() != nil {
+			r.logger.Info("Reconciliation cycle cancelled", "not_polled", len(instances)-i, "total", len(instances))
+			return
+		}
+
+		provider, ok := providers[instance.ProviderName]
+		if !ok {
+			provider, err = r.providers.GetByName(ctx, instance.ProviderName)
+			if err != nil && !errors.Is(err, store.ErrProviderNotFound) {
+				r.logger.Error("Error looking up provider", "provider_name", instance.ProviderName, "error", err)
+				continue
+			}
+			providers[instance.ProviderName] = provider
+		}
+		if provider == nil || provider.HealthStatus == model.HealthStatusNotReady || provider.HealthStatus == model.HealthStatusUnknown {
+			continue
+		}
+
+		if err := r.reconcileInstance(ctx, provider, instance); err != nil {
+			r.logger.Debug("Error reconciling instance", "provider_name", instance.ProviderName, "instance_id", instance.ID, "error", err)
+		}
+	}
+}
+
+func (r *Reconciler) reconcileInstance(ctx context.Context, provider *model.Provider, instance model.ServiceTypeInstance) error {
+	status, err := r.fetchStatus(ctx, provider, instance)
+	if err != nil {
+		return fmt.Errorf("polling provider %s: %w", provider.Name, err)
+	}
+	if status == "" || status == instance.Status {
+		return nil
+	}
+
+	if err := r.instances.UpdateStatus(ctx, instance.ID, status); err != nil {
+		return fmt.Errorf("updating status: %w", err)
+	}
+	r.logger.Info("Instance status changed", "provider_name", provider.Name, "instance_id", instance.ID,
+		"from", instance.Status, "to", status)
+	return nil
+}
+
+// maxStatusResponseSize bounds how much of a provider's status response is read, so
+// a misbehaving provider cannot make the reconciler buffer an unbounded body.
+const maxStatusResponseSize = 1 << 20
+
+// fetchStatus GETs the provider's get path for the provider-side ID of the instance,
+// {endpoint}/{id} by default, and returns the status field of the response.
+func (r *Reconciler) fetchStatus(ctx context.Context, provider *model.Provider, instance model.ServiceTypeInstance) (string, error) {
+	if err := r.allowlist.CheckEndpoint(provider.Endpoint); err != nil {
+		return "", fmt.Errorf("not contacted: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.GetURL(instance.ProviderID()), nil)
+	if err != nil {
+		return "", fmt.Errorf("creating status request: %w", err)
+	}
+	for name, values := range healthcheck.AuthHeader(*provider) {
+		req.Header[name] = values
+	}
+
+	start := time.Now()
+	resp, err := healthcheck.ProviderClient(ctx, r.httpClient, r.logger, *provider).Do(req)
+	if r.metrics != nil {
+		r.metrics.ProviderCallDuration.WithLabelValues(metrics.CallInstanceStatus).Observe(time.Since(start).Seconds())
+	}
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("status code %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxStatusResponseSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding status response: %w", err)
+	}
+	return body.Status, nil
+}
@@ -0,0 +1,220 @@
+package reconciler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/internal/config"
+	"github.com/dcm-project/service-provider-manager/internal/logging"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	"github.com/dcm-project/service-provider-manager/internal/reconciler"
+	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctx         context.Context
+		dataStore   store.Store
+		providerAPI *httptest.Server
+		requests    atomic.Int32
+		knownPath   atomic.Value
+		status      atomic.Value
+		cfg         *config.ReconcilerConfig
+		instance    *model.ServiceTypeInstance
+		rec         *reconciler.Reconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.AutoMigrate(&model.Provider{}, &model.ServiceTypeInstance{}, &model.HealthEvent{})).To(Succeed())
+		// Every :memory: connection is a separate database; the background loop must
+		// share the one the test set up.
+		sqlDB, err := db.DB()
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB.SetMaxOpenConns(1)
+		dataStore = store.NewStore(db)
+		DeferCleanup(dataStore.Close)
+
+		requests.Store(0)
+		status.Store("PROVISIONING")
+		providerAPI = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			if r.URL.Path != knownPath.Load().(string) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": status.Load().(string)})
+		}))
+		DeferCleanup(providerAPI.Close)
+
+		_, err = dataStore.Provider().Create(ctx, model.Provider{
+			ID:            uuid.New(),
+			Name:          "kubevirt",
+			ServiceType:   "vm",
+			SchemaVersion: "v1alpha1",
+			Endpoint:      providerAPI.URL,
+			HealthStatus:  model.HealthStatusReady,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		instance, err = dataStore.ServiceTypeInstance().Create(ctx, model.ServiceTypeInstance{
+			ID:           uuid.New(),
+			ProviderName: "kubevirt",
+			Status:       "PROVISIONING",
+			InstanceName: "vm-1",
+			Spec:         []byte(`{}`),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		knownPath.Store("/" + instance.ID.String())
+
+		cfg = &config.ReconcilerConfig{
+			Interval:         10 * time.Millisecond,
+			Timeout:          time.Second,
+			TerminalStatuses: []string{"RUNNING", "FAILED"},
+		}
+		rec = reconciler.NewReconciler(dataStore.ServiceTypeInstance(), dataStore.Provider(), cfg)
+	})
+
+	storedStatus := func() string {
+		stored, err := dataStore.ServiceTypeInstance().Get(ctx, instance.ID)
+		Expect(err).NotTo(HaveOccurred())
+		return stored.Status
+	}
+
+	It("stores the status reported by the provider", func() {
+		rec.Reconcile(ctx)
+		Expect(storedStatus()).To(Equal("PROVISIONING"))
+
+		status.Store("RUNNING")
+		rec.Reconcile(ctx)
+		Expect(storedStatus()).To(Equal("RUNNING"))
+	})
+
+	It("addresses the instance by its provider-side ID", func() {
+		Expect(dataStore.ServiceTypeInstance().Delete(ctx, instance.ID)).To(Succeed())
+		var err error
+		instance, err = dataStore.ServiceTypeInstance().Create(ctx, model.ServiceTypeInstance{
+			ID:                 uuid.New(),
+			ProviderName:       "kubevirt",
+			ProviderInstanceID: "kv-vm-1",
+			Status:             "PROVISIONING",
+			InstanceName:       "vm-1",
+			Spec:               []byte(`{}`),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		knownPath.Store("/kv-vm-1")
+		status.Store("RUNNING")
+
+		rec.Reconcile(ctx)
+
+		Expect(storedStatus()).To(Equal("RUNNING"))
+	})
+
+	It("polls the provider's get path", func() {
+		provider, err := dataStore.Provider().GetByName(ctx, "kubevirt")
+		Expect(err).NotTo(HaveOccurred())
+		provider.Endpoint = providerAPI.URL + "/"
+		provider.GetPath = "/v1/vms/{id}"
+		_, err = dataStore.Provider().Update(ctx, *provider)
+		Expect(err).NotTo(HaveOccurred())
+		knownPath.Store("/v1/vms/" + instance.ID.String())
+		status.Store("RUNNING")
+
+		rec.Reconcile(ctx)
+
+		Expect(storedStatus()).To(Equal("RUNNING"))
+	})
+
+	It("does not poll providers whose host is not on the allowlist", func() {
+		status.Store("RUNNING")
+		rec = reconciler.NewReconciler(dataStore.ServiceTypeInstance(), dataStore.Provider(), cfg,
+			reconciler.WithHostAllowlist(netpolicy.NewHostAllowlist([]string{"provider.example.com"})))
+
+		rec.Reconcile(ctx)
+
+		Expect(requests.Load()).To(BeZero())
+		Expect(storedStatus()).To(Equal("PROVISIONING"))
+	})
+
+	It("does not read more than a bounded status response", func() {
+		providerAPI.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"padding": "` + strings.Repeat("x", 2<<20) + `", "status": "RUNNING"}`))
+		})
+
+		rec.Reconcile(ctx)
+
+		Expect(storedStatus()).To(Equal("PROVISIONING"))
+	})
+
+	It("logs the calls to providers with debug logging", func() {
+		provider, err := dataStore.Provider().GetByName(ctx, "kubevirt")
+		Expect(err).NotTo(HaveOccurred())
+		provider.DebugLogging = true
+		_, err = dataStore.Provider().Update(ctx, *provider)
+		Expect(err).NotTo(HaveOccurred())
+		var logs bytes.Buffer
+		rec = reconciler.NewReconciler(dataStore.ServiceTypeInstance(), dataStore.Provider(), cfg,
+			reconciler.WithLogger(logging.New(&logs, slog.LevelInfo)))
+
+		rec.Reconcile(ctx)
+
+		Expect(logs.String()).To(ContainSubstring(`msg="Provider request" provider_name=kubevirt method=GET`))
+		Expect(logs.String()).To(ContainSubstring(`msg="Provider response" provider_name=kubevirt method=GET`))
+	})
+
+	It("stops polling instances once they reach a terminal status", func() {
+		status.Store("RUNNING")
+		rec.Reconcile(ctx)
+		rec.Reconcile(ctx)
+
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("skips instances of providers that are not ready", func() {
+		provider, err := dataStore.Provider().GetByName(ctx, "kubevirt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dataStore.Provider().UpdateHealthStatus(ctx, provider.ID, model.HealthStatusNotReady, 3, time.Now())).To(Succeed())
+		status.Store("RUNNING")
+
+		rec.Reconcile(ctx)
+
+		Expect(requests.Load()).To(BeZero())
+		Expect(storedStatus()).To(Equal("PROVISIONING"))
+	})
+
+	It("skips instances of providers that have not been checked yet", func() {
+		provider, err := dataStore.Provider().GetByName(ctx, "kubevirt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dataStore.Provider().UpdateHealthStatus(ctx, provider.ID, model.HealthStatusUnknown, 0, time.Now())).To(Succeed())
+
+		rec.Reconcile(ctx)
+
+		Expect(requests.Load()).To(BeZero())
+	})
+
+	It("polls in the background until stopped", func() {
+		rec.Start(ctx)
+		status.Store("RUNNING")
+
+		Eventually(storedStatus).Should(Equal("RUNNING"))
+		rec.Stop()
+	})
+})
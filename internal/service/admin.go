@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/dcm-project/service-provider-manager/internal/store"
+)
+
+// AdminService handles administrative operations such as schema maintenance.
+type AdminService struct {
+	store store.Store
+}
+
+// NewAdminService creates a new AdminService with the given store.
+func NewAdminService(store store.Store) *AdminService {
+	return &AdminService{store: store}
+}
+
+// CheckSchema reports whether the database schema matches the models.
+func (s *AdminService) CheckSchema(ctx context.Context) (*server.SchemaStatus, error) {
+	report, err := s.store.Schema().Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return schemaReportToAPI(report), nil
+}
+
+// MigrateSchema runs the migrations and returns the resulting schema status.
+// Destructive changes (dropping unmapped columns) are only applied when allowDestructive is set.
+func (s *AdminService) MigrateSchema(ctx context.Context, allowDestructive bool) (*server.SchemaStatus, error) {
+	report, err := s.store.Schema().Migrate(ctx, allowDestructive)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Migrated database schema", "allow_destructive", allowDestructive, "in_sync", report.InSync())
+	return schemaReportToAPI(report), nil
+}
+
+func schemaReportToAPI(report *store.SchemaReport) *server.SchemaStatus {
+	status := &server.SchemaStatus{InSync: report.InSync()}
+	if len(report.MissingTables) > 0 {
+		status.MissingTables = &report.MissingTables
+	}
+	if len(report.MissingColumns) > 0 {
+		status.MissingColumns = &report.MissingColumns
+	}
+	if len(report.ExtraColumns) > 0 {
+		status.ExtraColumns = &report.ExtraColumns
+	}
+	return status
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
+)
+
+// providerCache is a bounded LRU cache of providers keyed by ID with a per-entry TTL.
+type providerCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[uuid.UUID]*list.Element
+	order   *list.List // front is most recently used
+
+	// generation is bumped on every invalidation so that a read which started before
+	// an update cannot repopulate the cache with the pre-update value.
+	generation uint64
+}
+
+type providerCacheEntry struct {
+	provider model.Provider
+	expires  time.Time
+}
+
+func newProviderCache(size int, ttl time.Duration) *providerCache {
+	return &providerCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// get returns a copy of the cached provider and whether it was found and not expired,
+// along with the generation to pass to put after loading on a miss.
+func (c *providerCache) get(id uuid.UUID, now time.Time) (*model.Provider, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil, c.generation, false
+	}
+	entry := elem.Value.(*providerCacheEntry)
+	if now.After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, id)
+		return nil, c.generation, false
+	}
+
+	c.order.MoveToFront(elem)
+	provider := entry.provider
+	return &provider, c.generation, true
+}
+
+// put stores a copy of the provider unless the cache was invalidated since generation.
+func (c *providerCache) put(provider *model.Provider, generation uint64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	entry := &providerCacheEntry{provider: *provider, expires: now.Add(c.ttl)}
+	if elem, ok := c.entries[provider.ID]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[provider.ID] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*providerCacheEntry).provider.ID)
+	}
+}
+
+// invalidate evicts the provider so the next read goes to the store.
+func (c *providerCache) invalidate(id uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if elem, ok := c.entries[id]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, id)
+	}
+}
@@ -1,27 +1,76 @@
 package service
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
 	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
+	"gorm.io/datatypes"
 )
 
 // ModelToProvider converts a database model to an API response type
 func ModelToProvider(m *model.Provider) *server.Provider {
 	id := openapi_types.UUID(m.ID)
-	return &server.Provider{
-		Id:            &id,
-		Name:          m.Name,
-		ServiceType:   m.ServiceType,
-		SchemaVersion: m.SchemaVersion,
-		Endpoint:      m.Endpoint,
-		HealthStatus:  m.HealthStatus.StringPtr(),
-		CreateTime:    ptrTime(m.CreateTime),
-		UpdateTime:    ptrTime(m.UpdateTime),
+	p := &server.Provider{
+		Id:                  &id,
+		Name:                m.Name,
+		ServiceType:         m.ServiceType,
+		SchemaVersion:       m.SchemaVersion,
+		Endpoint:            m.Endpoint,
+		HealthStatus:        m.HealthStatus.StringPtr(),
+		ConsecutiveFailures: &m.ConsecutiveFailures,
+		NextHealthCheckTime: m.NextHealthCheck,
+		CreateTime:          ptrTime(m.CreateTime),
+		UpdateTime:          ptrTime(m.UpdateTime),
+		DebugLogging:        &m.DebugLogging,
+		TimeoutSeconds:      &m.TimeoutSeconds,
+		HealthCheckDisabled: &m.HealthCheckDisabled,
+		Version:             &m.Version,
 	}
+	if len(m.Labels) > 0 {
+		labels := make(map[string]string, len(m.Labels))
+		for key, value := range m.Labels {
+			labels[key] = fmt.Sprint(value)
+		}
+		p.Labels = &labels
+	}
+	if m.CreatePath != "" {
+		p.CreatePath = &m.CreatePath
+	}
+	if m.GetPath != "" {
+		p.GetPath = &m.GetPath
+	}
+	if m.DeletePath != "" {
+		p.DeletePath = &m.DeletePath
+	}
+	healthPath := model.DefaultHealthPath
+	if m.HealthPath != nil {
+		healthPath = *m.HealthPath
+	}
+	p.HealthPath = &healthPath
+	if len(m.HealthExpectedStatuses) > 0 {
+		statuses := slices.Clone(m.HealthExpectedStatuses)
+		p.HealthExpectedStatuses = &statuses
+	}
+	if m.AuthType != "" {
+		// The token is write-only and never leaves the service.
+		p.Auth = &server.ProviderAuth{Type: server.ProviderAuthType(m.AuthType)}
+		if m.AuthHeader != "" {
+			p.Auth.HeaderName = &m.AuthHeader
+		}
+	}
+	if m.MaintenanceStart != nil && m.MaintenanceEnd != nil {
+		p.MaintenanceWindow = &server.MaintenanceWindow{
+			StartTime: *m.MaintenanceStart,
+			EndTime:   *m.MaintenanceEnd,
+		}
+	}
+	return p
 }
 
 // ModelToProviderWithStatus converts a database model to an API response with status
@@ -31,10 +80,29 @@ func ModelToProviderWithStatus(m *model.Provider, status server.ProviderStatus)
 	return p
 }
 
-// ProviderToModel converts an API request to a database model
-func ProviderToModel(req *server.Provider, id uuid.UUID) model.Provider {
-	now := time.Now()
-	return model.Provider{
+// ModelToHealthEvent converts a stored health check outcome to an API response type
+func ModelToHealthEvent(m *model.HealthEvent) server.HealthEvent {
+	e := server.HealthEvent{
+		Time:         m.CheckTime,
+		HealthStatus: string(m.Status),
+		LatencyMs:    m.LatencyMs,
+	}
+	if m.HTTPStatus != 0 {
+		e.HttpStatus = &m.HTTPStatus
+	}
+	if m.Error != "" {
+		e.Error = &m.Error
+	}
+	e.CertNotAfter = m.CertNotAfter
+	if m.Warning != "" {
+		e.Warning = &m.Warning
+	}
+	return e
+}
+
+// ProviderToModel converts an API request to a database model created at now
+func ProviderToModel(req *server.Provider, id uuid.UUID, now time.Time) model.Provider {
+	m := model.Provider{
 		ID:            id,
 		Name:          req.Name,
 		ServiceType:   req.ServiceType,
@@ -42,6 +110,95 @@ func ProviderToModel(req *server.Provider, id uuid.UUID) model.Provider {
 		Endpoint:      req.Endpoint,
 		CreateTime:    now,
 		UpdateTime:    now,
+		DebugLogging:  req.DebugLogging != nil && *req.DebugLogging,
+	}
+	setMaintenanceWindow(&m, req.MaintenanceWindow)
+	setAuth(&m, req.Auth)
+	setTimeout(&m, req.TimeoutSeconds)
+	setHealthCheck(&m, req)
+	setInstancePaths(&m, req)
+	setLabels(&m, req.Labels)
+	return m
+}
+
+// setMaintenanceWindow copies the API maintenance window to the model, clearing it when nil
+func setMaintenanceWindow(m *model.Provider, window *server.MaintenanceWindow) {
+	m.MaintenanceStart, m.MaintenanceEnd = nil, nil
+	if window != nil {
+		start, end := window.StartTime, window.EndTime
+		m.MaintenanceStart, m.MaintenanceEnd = &start, &end
+	}
+}
+
+// setAuth copies the API credentials to the model, clearing them when nil. A
+// missing token keeps the stored one as long as the auth type is unchanged, since
+// the token is never returned to be sent back.
+func setAuth(m *model.Provider, auth *server.ProviderAuth) {
+	if auth == nil {
+		m.AuthType, m.AuthHeader, m.AuthToken = "", "", ""
+		return
+	}
+
+	authType := model.AuthType(auth.Type)
+	if auth.Token != nil && *auth.Token != "" {
+		m.AuthToken = *auth.Token
+	} else if authType != m.AuthType {
+		m.AuthToken = ""
+	}
+	m.AuthType = authType
+	m.AuthHeader = ""
+	if auth.HeaderName != nil {
+		m.AuthHeader = *auth.HeaderName
+	}
+}
+
+// setTimeout copies the timeout override to the model, clamped to the maximum the
+// monitor applies. nil clears it.
+func setTimeout(m *model.Provider, seconds *int) {
+	m.TimeoutSeconds = 0
+	if seconds != nil {
+		m.TimeoutSeconds = int(healthcheck.ProviderTimeout(*seconds) / time.Second)
+	}
+}
+
+// setHealthCheck copies the health check settings to the model. A nil path is
+// stored as the default so that responses show the path that is checked.
+func setHealthCheck(m *model.Provider, req *server.Provider) {
+	path := model.DefaultHealthPath
+	if req.HealthPath != nil {
+		path = *req.HealthPath
+	}
+	m.HealthPath = &path
+	m.HealthExpectedStatuses = nil
+	if req.HealthExpectedStatuses != nil && len(*req.HealthExpectedStatuses) > 0 {
+		m.HealthExpectedStatuses = slices.Clone(*req.HealthExpectedStatuses)
+	}
+	m.HealthCheckDisabled = req.HealthCheckDisabled != nil && *req.HealthCheckDisabled
+}
+
+// setInstancePaths copies the instance API paths to the model, clearing the ones
+// that are nil.
+func setInstancePaths(m *model.Provider, req *server.Provider) {
+	m.CreatePath, m.GetPath, m.DeletePath = "", "", ""
+	if req.CreatePath != nil {
+		m.CreatePath = *req.CreatePath
+	}
+	if req.GetPath != nil {
+		m.GetPath = *req.GetPath
+	}
+	if req.DeletePath != nil {
+		m.DeletePath = *req.DeletePath
+	}
+}
+
+// setLabels copies the API labels to the model, clearing them when nil or empty.
+func setLabels(m *model.Provider, labels *map[string]string) {
+	m.Labels = nil
+	if labels != nil && len(*labels) > 0 {
+		m.Labels = make(datatypes.JSONMap, len(*labels))
+		for key, value := range *labels {
+			m.Labels[key] = value
+		}
 	}
 }
 
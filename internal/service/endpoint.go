@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dcm-project/service-provider-manager/internal/api/server"
+)
+
+// EndpointSchemePolicy controls how provider endpoints using plain http are handled.
+type EndpointSchemePolicy string
+
+const (
+	// EndpointSchemeAllowHTTP stores http endpoints as given.
+	EndpointSchemeAllowHTTP EndpointSchemePolicy = "allow-http"
+	// EndpointSchemeUpgradeHTTP rewrites http endpoints to https before storing them.
+	EndpointSchemeUpgradeHTTP EndpointSchemePolicy = "upgrade-http"
+	// EndpointSchemeRequireHTTPS rejects http endpoints.
+	EndpointSchemeRequireHTTPS EndpointSchemePolicy = "require-https"
+)
+
+// ParseEndpointSchemePolicy returns the policy named by value.
+func ParseEndpointSchemePolicy(value string) (EndpointSchemePolicy, error) {
+	switch policy := EndpointSchemePolicy(value); policy {
+	case EndpointSchemeAllowHTTP, EndpointSchemeUpgradeHTTP, EndpointSchemeRequireHTTPS:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown endpoint scheme policy %q", value)
+}
+
+// applyEndpointPolicy returns the request with its endpoint adjusted to the scheme
+// policy, or ErrCodeValidation if the policy or the host allowlist rejects it. The
+// request is copied rather than modified when the endpoint is rewritten.
+func (s *ProviderService) applyEndpointPolicy(req *server.Provider) (*server.Provider, error) {
+	if err := s.hostAllowlist.CheckEndpoint(req.Endpoint); err != nil {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("endpoint %s is not allowed: %v", req.Endpoint, err)}
+	}
+
+	if s.endpointSchemePolicy == EndpointSchemeAllowHTTP {
+		return req, nil
+	}
+
+	u, err := url.Parse(req.Endpoint)
+	if err != nil || !strings.EqualFold(u.Scheme, "http") {
+		return req, nil
+	}
+
+	if s.endpointSchemePolicy == EndpointSchemeRequireHTTPS {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("endpoint %s must use https", req.Endpoint)}
+	}
+
+	u.Scheme = "https"
+	upgraded := *req
+	upgraded.Endpoint = u.String()
+	return &upgraded, nil
+}
@@ -2,9 +2,10 @@ package service
 
 // Error codes returned by service operations.
 const (
-	ErrCodeNotFound   = "NOT_FOUND"
-	ErrCodeConflict   = "CONFLICT"
-	ErrCodeValidation = "VALIDATION"
+	ErrCodeNotFound      = "NOT_FOUND"
+	ErrCodeConflict      = "CONFLICT"
+	ErrCodeValidation    = "VALIDATION"
+	ErrCodeProviderError = "PROVIDER_ERROR"
 )
 
 // ServiceError represents a business logic error with a code for HTTP mapping.
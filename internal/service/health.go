@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/dcm-project/service-provider-manager/internal/store"
+)
+
+const (
+	healthStatusOK       = "ok"
+	healthStatusDegraded = "degraded"
+	healthStatusNotReady = "not_ready"
+	componentStatusOK    = "ok"
+	componentStatusError = "error"
+)
+
+// HealthChecker reports the health of a single component.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckFunc adapts a function to the HealthChecker interface.
+type HealthCheckFunc func(ctx context.Context) error
+
+func (f HealthCheckFunc) CheckHealth(ctx context.Context) error {
+	return f(ctx)
+}
+
+// HealthService aggregates the health of the service components.
+type HealthService struct {
+	components map[string]HealthChecker
+	readiness  map[string]HealthChecker
+}
+
+// NewHealthService creates a new HealthService reporting the given components in
+// the health resource. The service is ready while every readiness check passes.
+func NewHealthService(components, readiness map[string]HealthChecker) *HealthService {
+	return &HealthService{components: components, readiness: readiness}
+}
+
+// GetHealth checks every component. The overall status is "ok" when all components
+// are healthy and "degraded" when any of them reports an error.
+func (s *HealthService) GetHealth(ctx context.Context) *server.Health {
+	status := healthStatusOK
+	components, healthy := check(ctx, s.components)
+	if !healthy {
+		status = healthStatusDegraded
+	}
+
+	path := "health"
+	return &server.Health{Status: &status, Path: &path, Components: &components}
+}
+
+// GetLiveness reports that the process is up, without checking any component.
+func (s *HealthService) GetLiveness() *server.Health {
+	status := healthStatusOK
+	path := "livez"
+	return &server.Health{Status: &status, Path: &path}
+}
+
+// GetReadiness runs the readiness checks and reports whether all of them passed.
+// The status is "not_ready" when any of them fails.
+func (s *HealthService) GetReadiness(ctx context.Context) (*server.Health, bool) {
+	status := healthStatusOK
+	components, ready := check(ctx, s.readiness)
+	if !ready {
+		status = healthStatusNotReady
+	}
+
+	path := "readyz"
+	return &server.Health{Status: &status, Path: &path, Components: &components}, ready
+}
+
+// check runs every checker and reports whether all of them passed. The health
+// endpoints are unauthenticated, so the reason a check failed is logged rather than
+// returned.
+func check(ctx context.Context, checkers map[string]HealthChecker) (map[string]server.ComponentHealth, bool) {
+	healthy := true
+	components := make(map[string]server.ComponentHealth, len(checkers))
+
+	for name, checker := range checkers {
+		if err := checker.CheckHealth(ctx); err != nil {
+			slog.WarnContext(ctx, "Health check failed", "component", name, "error", err)
+			components[name] = server.ComponentHealth{Status: componentStatusError}
+			healthy = false
+			continue
+		}
+		components[name] = server.ComponentHealth{Status: componentStatusOK}
+	}
+	return components, healthy
+}
+
+// SchemaMigrated checks that the schema migrations have been applied to the database.
+func SchemaMigrated(schema store.Schema) HealthChecker {
+	return HealthCheckFunc(func(ctx context.Context) error {
+		report, err := schema.Check(ctx)
+		if err != nil {
+			return err
+		}
+		if !report.Migrated() {
+			return fmt.Errorf("schema not migrated: missing tables %v, missing columns %v", report.MissingTables, report.MissingColumns)
+		}
+		return nil
+	})
+}
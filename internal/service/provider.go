@@ -2,14 +2,22 @@ package service
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
-	"log"
-	"strconv"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/dcm-project/service-provider-manager/internal/clock"
+	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	"github.com/dcm-project/service-provider-manager/internal/pagination"
 	"github.com/dcm-project/service-provider-manager/internal/store"
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
 	"github.com/google/uuid"
@@ -19,28 +27,199 @@ import (
 const (
 	defaultPageSize = 100
 	maxPageSize     = 100
+
+	// maxRegisterAttempts bounds how often a registration is retried after losing
+	// a race on the unique provider name.
+	maxRegisterAttempts = 2
+
+	defaultProbeTimeout = 5 * time.Second
 )
 
+// DefaultSchemaVersions are the schema versions accepted unless WithSchemaVersions
+// sets others.
+var DefaultSchemaVersions = []string{"v1alpha1"}
+
 // ListResult contains the result of listing providers with pagination info.
 type ListResult struct {
 	Providers     []server.Provider
 	NextPageToken string
+	// TotalSize is the number of providers matching the filter across all pages,
+	// nil when it was not counted
+	TotalSize *int64
 }
 
 // ProviderService handles business logic for provider management.
 type ProviderService struct {
-	store store.Store
+	store                store.Store
+	cache                *providerCache
+	probeClient          *http.Client
+	endpointSchemePolicy EndpointSchemePolicy
+	hostAllowlist        *netpolicy.HostAllowlist
+	healthChecker        ProviderHealthChecker
+	clock                clock.Clock
+	strictPageTokens     bool
+	defaultPageSize      int
+	maxPageSize          int
+	schemaVersions       []string
+	logger               *slog.Logger
+	metrics              *metrics.Metrics
+}
+
+// ProviderServiceOption configures optional ProviderService behavior.
+type ProviderServiceOption func(*ProviderService)
+
+// WithResponseCache caches GetProvider results for up to ttl, keeping at most size
+// providers. Updates and deletes through the service, and InvalidateCachedProvider,
+// evict the affected provider.
+// Caching is disabled when size is not positive.
+func WithResponseCache(size int, ttl time.Duration) ProviderServiceOption {
+	return func(s *ProviderService) {
+		if size > 0 && ttl > 0 {
+			s.cache = newProviderCache(size, ttl)
+		}
+	}
+}
+
+// WithEndpointProbeTimeout sets the timeout for probing a new endpoint before an
+// update switches to it.
+func WithEndpointProbeTimeout(timeout time.Duration) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.probeClient.Timeout = timeout
+	}
+}
+
+// WithProviderTransport sets the transport used to probe endpoints, for example one
+// that trusts a private CA.
+func WithProviderTransport(transport http.RoundTripper) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.probeClient.Transport = transport
+	}
+}
+
+// WithEndpointSchemePolicy sets how endpoints using plain http are handled on
+// registration and update. The default is EndpointSchemeAllowHTTP.
+func WithEndpointSchemePolicy(policy EndpointSchemePolicy) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.endpointSchemePolicy = policy
+	}
+}
+
+// WithHostAllowlist rejects registrations and updates whose endpoint host is not on
+// the allowlist.
+func WithHostAllowlist(allowlist *netpolicy.HostAllowlist) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.hostAllowlist = allowlist
+	}
+}
+
+// WithLogger sets the logger for provider lifecycle events.
+func WithLogger(logger *slog.Logger) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.logger = logger
+	}
+}
+
+// WithMetrics records provider operations in m.
+func WithMetrics(m *metrics.Metrics) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.metrics = m
+	}
+}
+
+// WithClock sets the time source for create and update times and cache expiry.
+func WithClock(c clock.Clock) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.clock = c
+	}
+}
+
+// WithStrictPageTokens rejects page tokens that point past the end of the results,
+// for example after the remaining providers were deleted, with ErrCodeValidation.
+// By default such a token returns an empty page without a next page token.
+func WithStrictPageTokens(strict bool) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.strictPageTokens = strict
+	}
+}
+
+// WithPageSizes sets the page size used when a list request does not ask for one,
+// and the largest page size served; larger requests are clamped to it. Sizes that
+// are not positive keep the defaults of 100.
+func WithPageSizes(defaultSize, maxSize int) ProviderServiceOption {
+	return func(s *ProviderService) {
+		if maxSize > 0 {
+			s.maxPageSize = maxSize
+		}
+		if defaultSize > 0 {
+			s.defaultPageSize = defaultSize
+		}
+		s.defaultPageSize = min(s.defaultPageSize, s.maxPageSize)
+	}
+}
+
+// WithSchemaVersions sets the schema versions providers can be registered and
+// updated with. An empty list keeps DefaultSchemaVersions.
+func WithSchemaVersions(versions ...string) ProviderServiceOption {
+	return func(s *ProviderService) {
+		if len(versions) > 0 {
+			s.schemaVersions = slices.Clone(versions)
+		}
+	}
 }
 
 // NewProviderService creates a new ProviderService with the given store.
-func NewProviderService(store store.Store) *ProviderService {
-	return &ProviderService{store: store}
+func NewProviderService(store store.Store, opts ...ProviderServiceOption) *ProviderService {
+	s := &ProviderService{
+		store:                store,
+		probeClient:          &http.Client{Timeout: defaultProbeTimeout},
+		endpointSchemePolicy: EndpointSchemeAllowHTTP,
+		clock:                clock.Real{},
+		defaultPageSize:      defaultPageSize,
+		maxPageSize:          maxPageSize,
+		schemaVersions:       DefaultSchemaVersions,
+		logger:               slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // RegisterOrUpdateProvider implements idempotent provider registration per the DCM spec.
 // Returns status "registered" for new providers, "updated" for existing ones.
 // Returns ErrCodeConflict if name exists with different ID or ID exists with different name.
-func (s *ProviderService) RegisterOrUpdateProvider(ctx context.Context, req *server.Provider, queryID *openapi_types.UUID) (*server.Provider, error) {
+// With validateEndpoint set, a new provider, or an existing one whose endpoint
+// changes, must pass a health probe first; otherwise ErrCodeProviderError is
+// returned and nothing is stored.
+func (s *ProviderService) RegisterOrUpdateProvider(ctx context.Context, req *server.Provider, queryID *openapi_types.UUID, validateEndpoint bool) (*server.Provider, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := s.registerOrUpdateProvider(ctx, req, queryID, validateEndpoint)
+		if errors.Is(err, store.ErrProviderNameTaken) && attempt < maxRegisterAttempts {
+			// A concurrent registration inserted the same name between the lookup and
+			// the insert. Retrying finds that provider and performs an idempotent update.
+			s.logger.Info("Provider was registered concurrently, retrying", "provider_name", req.Name)
+			continue
+		}
+		if errors.Is(err, store.ErrProviderNameTaken) {
+			// Still losing the race after retrying; report it like any other
+			// duplicate name instead of as an internal error.
+			err = &ServiceError{Code: ErrCodeConflict, Message: fmt.Sprintf("name '%s' is already taken", req.Name)}
+		}
+		operation := "create"
+		if resp != nil && resp.Status != nil && *resp.Status == server.Updated {
+			operation = "update"
+		}
+		s.recordOperation(operation, err)
+		return resp, err
+	}
+}
+
+func (s *ProviderService) registerOrUpdateProvider(ctx context.Context, req *server.Provider, queryID *openapi_types.UUID, validateEndpoint bool) (*server.Provider, error) {
+	req, err := s.validateProvider(req)
+	if err != nil {
+		return nil, err
+	}
+
 	requestedID := s.parseProviderID(req.Id, queryID)
 
 	existing, err := s.findExistingByName(ctx, req.Name, requestedID)
@@ -49,6 +228,11 @@ func (s *ProviderService) RegisterOrUpdateProvider(ctx context.Context, req *ser
 	}
 
 	if existing != nil {
+		if validateEndpoint && req.Endpoint != existing.Endpoint {
+			if err := s.probeEndpoint(ctx, *existing, req); err != nil {
+				return nil, err
+			}
+		}
 		updated, err := s.updateExistingProvider(ctx, existing, req)
 		if err != nil {
 			return nil, err
@@ -61,16 +245,192 @@ func (s *ProviderService) RegisterOrUpdateProvider(ctx context.Context, req *ser
 		return nil, err
 	}
 
-	providerModel := ProviderToModel(req, providerID)
+	providerModel := ProviderToModel(req, providerID, s.clock.Now())
+	if err := validateAuth(&providerModel); err != nil {
+		return nil, err
+	}
+	// A new provider is not used until its first health check shows it is ready.
+	// Providers that opt out of health checks are ready right away.
+	providerModel.HealthStatus = model.HealthStatusUnknown
+	if providerModel.HealthCheckDisabled {
+		providerModel.HealthStatus = model.HealthStatusReady
+	}
+	if validateEndpoint {
+		if err := s.probeEndpoint(ctx, providerModel, req); err != nil {
+			return nil, err
+		}
+		// The probe just succeeded, so the provider starts out ready.
+		providerModel.HealthStatus = model.HealthStatusReady
+	}
 	created, err := s.store.Provider().Create(ctx, providerModel)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("Created provider: %s (%s)", created.Name, created.ID)
+	s.logger.Info("Created provider", "provider_name", created.Name, "provider_id", created.ID)
 	return ModelToProviderWithStatus(created, server.Registered), nil
 }
 
+// validateProvider checks the settings of a registration or full update and returns
+// the request with the endpoint policy applied.
+func (s *ProviderService) validateProvider(req *server.Provider) (*server.Provider, error) {
+	if err := validateMaintenanceWindow(req.MaintenanceWindow); err != nil {
+		return nil, err
+	}
+	if err := validateTimeout(req.TimeoutSeconds); err != nil {
+		return nil, err
+	}
+	if err := validateHealthPath(req.HealthPath); err != nil {
+		return nil, err
+	}
+	if err := validateHealthExpectedStatuses(req.HealthExpectedStatuses); err != nil {
+		return nil, err
+	}
+	if err := validateInstancePaths(req); err != nil {
+		return nil, err
+	}
+	if err := validateLabels(req.Labels); err != nil {
+		return nil, err
+	}
+	if err := s.validateSchemaVersion(req.SchemaVersion); err != nil {
+		return nil, err
+	}
+	return s.applyEndpointPolicy(req)
+}
+
+// validateAuth checks the provider's credentials once the request has been applied
+// to the model, so a token kept from the stored provider counts as set.
+func validateAuth(m *model.Provider) error {
+	switch m.AuthType {
+	case "":
+		return nil
+	case model.AuthTypeBearer:
+	case model.AuthTypeHeader:
+		if m.AuthHeader == "" {
+			return &ServiceError{Code: ErrCodeValidation, Message: "auth header_name is required for the header type"}
+		}
+	default:
+		return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid auth type %q", m.AuthType)}
+	}
+	if m.AuthToken == "" {
+		return &ServiceError{Code: ErrCodeValidation, Message: "auth token is required"}
+	}
+	return nil
+}
+
+func validateMaintenanceWindow(window *server.MaintenanceWindow) error {
+	if window != nil && !window.EndTime.After(window.StartTime) {
+		return &ServiceError{Code: ErrCodeValidation, Message: "maintenance_window end_time must be after start_time"}
+	}
+	return nil
+}
+
+func validateTimeout(seconds *int) error {
+	if seconds != nil && *seconds < 0 {
+		return &ServiceError{Code: ErrCodeValidation, Message: "timeout_seconds must not be negative"}
+	}
+	return nil
+}
+
+// validateHealthPath requires the health path, when set, to be a plain path, so it
+// cannot add a query or fragment to the health check URL.
+func validateHealthPath(path *string) error {
+	if path == nil || *path == "" {
+		return nil
+	}
+	u, err := url.Parse(*path)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Opaque != "" || u.RawQuery != "" || u.Fragment != "" {
+		return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid health_path %q, expected a path", *path)}
+	}
+	return nil
+}
+
+func validateHealthExpectedStatuses(statuses *[]int) error {
+	if statuses == nil {
+		return nil
+	}
+	for _, code := range *statuses {
+		if code < 100 || code > 599 {
+			return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid health_expected_statuses code %d", code)}
+		}
+	}
+	return nil
+}
+
+// validateInstancePaths requires the get and delete paths, when set, to contain the
+// instance ID placeholder, which the create path cannot use.
+func validateInstancePaths(req *server.Provider) error {
+	if req.CreatePath != nil && strings.Contains(*req.CreatePath, model.InstanceIDPlaceholder) {
+		return &ServiceError{Code: ErrCodeValidation, Message: "create_path must not contain " + model.InstanceIDPlaceholder}
+	}
+	if req.GetPath != nil && *req.GetPath != "" && !strings.Contains(*req.GetPath, model.InstanceIDPlaceholder) {
+		return &ServiceError{Code: ErrCodeValidation, Message: "get_path must contain " + model.InstanceIDPlaceholder}
+	}
+	if req.DeletePath != nil && *req.DeletePath != "" && !strings.Contains(*req.DeletePath, model.InstanceIDPlaceholder) {
+		return &ServiceError{Code: ErrCodeValidation, Message: "delete_path must contain " + model.InstanceIDPlaceholder}
+	}
+	return nil
+}
+
+// SchemaVersions returns the schema versions providers can be registered with.
+func (s *ProviderService) SchemaVersions() []string {
+	return slices.Clone(s.schemaVersions)
+}
+
+func (s *ProviderService) validateSchemaVersion(version string) error {
+	if !slices.Contains(s.schemaVersions, version) {
+		return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("unsupported schema_version %q, supported versions are %s",
+			version, strings.Join(s.schemaVersions, ", "))}
+	}
+	return nil
+}
+
+// maxLabelLength is the maximum length of a label key or value.
+const maxLabelLength = 63
+
+// validLabel reports whether s can be a label key or value: 1 to maxLabelLength
+// letters, digits, '.', '_', '/' or '-'. This keeps labels usable in selectors.
+func validLabel(s string) bool {
+	if s == "" || len(s) > maxLabelLength {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("._/-", c)) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateLabels(labels *map[string]string) error {
+	if labels == nil {
+		return nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(*labels)) {
+		if !validLabel(key) {
+			return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid label key %q", key)}
+		}
+		if !validLabel((*labels)[key]) {
+			return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid value for label %q", key)}
+		}
+	}
+	return nil
+}
+
+// parseLabelSelector parses comma-separated key=value pairs, e.g.
+// "region=us-east,team=infra".
+func parseLabelSelector(selector string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(selector, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || !validLabel(key) || !validLabel(value) {
+			return nil, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid label selector %q, expected key=value pairs", selector)}
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
 // parseProviderID extracts the provider ID from request body or query parameter.
 func (s *ProviderService) parseProviderID(bodyID *openapi_types.UUID, queryID *openapi_types.UUID) *uuid.UUID {
 	if bodyID != nil {
@@ -125,19 +485,42 @@ func (s *ProviderService) resolveProviderID(ctx context.Context, requestedID *uu
 	return *requestedID, nil
 }
 
+// updateExistingProvider applies the request to the existing provider. When the
+// request carries a version, the provider must still have it.
 func (s *ProviderService) updateExistingProvider(ctx context.Context, existing *model.Provider, req *server.Provider) (*model.Provider, error) {
+	if req.Version != nil && *req.Version != existing.Version {
+		return nil, errProviderModified
+	}
 	existing.Name = req.Name
 	existing.ServiceType = req.ServiceType
 	existing.SchemaVersion = req.SchemaVersion
 	existing.Endpoint = req.Endpoint
-	existing.UpdateTime = time.Now()
+	setMaintenanceWindow(existing, req.MaintenanceWindow)
+	setAuth(existing, req.Auth)
+	setTimeout(existing, req.TimeoutSeconds)
+	setHealthCheck(existing, req)
+	setInstancePaths(existing, req)
+	setLabels(existing, req.Labels)
+	if err := validateAuth(existing); err != nil {
+		return nil, err
+	}
+	existing.DebugLogging = req.DebugLogging != nil && *req.DebugLogging
+	existing.UpdateTime = s.clock.Now()
 
 	updated, err := s.store.Provider().Update(ctx, *existing)
 	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrProviderModified):
+			return nil, errProviderModified
+		case errors.Is(err, store.ErrProviderNameTaken):
+			// Another provider took the name after it was checked.
+			return nil, &ServiceError{Code: ErrCodeConflict, Message: fmt.Sprintf("name '%s' is already taken", existing.Name)}
+		}
 		return nil, err
 	}
+	s.invalidateCache(updated.ID)
 
-	log.Printf("Updated provider: %s (%s)", updated.Name, updated.ID)
+	s.logger.Info("Updated provider", "provider_name", updated.Name, "provider_id", updated.ID)
 	return updated, nil
 }
 
@@ -148,7 +531,7 @@ func (s *ProviderService) GetProvider(ctx context.Context, providerID string) (*
 		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
 	}
 
-	provider, err := s.store.Provider().Get(ctx, id)
+	provider, err := s.getCached(ctx, id)
 	if err != nil {
 		if errors.Is(err, store.ErrProviderNotFound) {
 			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider %s not found", providerID)}
@@ -159,88 +542,296 @@ func (s *ProviderService) GetProvider(ctx context.Context, providerID string) (*
 	return ModelToProvider(provider), nil
 }
 
+// GetProviderByName retrieves a provider by its name. Returns ErrCodeNotFound if not found.
+func (s *ProviderService) GetProviderByName(ctx context.Context, name string) (*server.Provider, error) {
+	if name == "" {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "name is required"}
+	}
+
+	provider, err := s.store.Provider().GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, store.ErrProviderNotFound) {
+			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider '%s' not found", name)}
+		}
+		return nil, err
+	}
+
+	return ModelToProvider(provider), nil
+}
+
+// getCached reads a provider through the response cache when it is enabled.
+func (s *ProviderService) getCached(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	if s.cache == nil {
+		return s.store.Provider().Get(ctx, id)
+	}
+
+	cached, generation, ok := s.cache.get(id, s.clock.Now())
+	if ok {
+		return cached, nil
+	}
+
+	provider, err := s.store.Provider().Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.put(provider, generation, s.clock.Now())
+	return provider, nil
+}
+
+// InvalidateCachedProvider evicts the provider from the response cache. It is for
+// writes that bypass the service, such as the health monitor storing check results.
+func (s *ProviderService) InvalidateCachedProvider(id uuid.UUID) {
+	s.invalidateCache(id)
+}
+
+func (s *ProviderService) invalidateCache(id uuid.UUID) {
+	if s.cache != nil {
+		s.cache.invalidate(id)
+	}
+}
+
 // ListProviders returns providers with pagination support per AEP-158.
-func (s *ProviderService) ListProviders(ctx context.Context, serviceType string, requestedPageSize int, pageToken string) (*ListResult, error) {
+// Non-empty serviceType, healthStatus, nameContains and labels filter the providers,
+// combined with AND; nameContains matches a substring of the name and labels is a
+// selector of comma-separated key=value pairs that must all be set.
+// orderBy is a field, name, create_time or update_time, optionally followed by asc
+// or desc; empty lists by create_time. A page token only continues the order it was
+// issued for.
+// With includeCounts set, each provider also carries its number of service type instances.
+// The matching providers are counted for TotalSize unless skipTotalSize is set.
+func (s *ProviderService) ListProviders(ctx context.Context, serviceType, healthStatus, nameContains, labels string, requestedPageSize int, pageToken, orderBy string, includeCounts, skipTotalSize bool) (*ListResult, error) {
 	// Validate and normalize page size per AEP-158
 	pageSize := requestedPageSize
 	if pageSize < 0 {
 		return nil, &ServiceError{Code: ErrCodeValidation, Message: "max_page_size must not be negative"}
 	}
 	if pageSize == 0 {
-		pageSize = defaultPageSize
+		pageSize = s.defaultPageSize
 	}
-	if pageSize > maxPageSize {
-		pageSize = maxPageSize
+	if pageSize > s.maxPageSize {
+		pageSize = s.maxPageSize
 	}
 
-	// Decode page token to get offset
-	offset := 0
+	order, err := parseOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode page token to get the cursor, or the offset of a legacy token
+	pagination := &store.Pagination{Limit: pageSize + 1, Order: &order}
 	if pageToken != "" {
-		decoded, err := decodePageToken(pageToken)
+		after, offset, err := decodePageToken(pageToken, order)
 		if err != nil {
+			if errors.Is(err, errPageTokenOrder) {
+				return nil, &ServiceError{Code: ErrCodeValidation, Message: "page_token was issued for a different order_by, restart from the first page"}
+			}
 			return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid page_token"}
 		}
-		offset = decoded
+		pagination.After, pagination.Offset = after, offset
 	}
 
 	// Build filter
-	var filter *store.ProviderFilter
+	filter := &store.ProviderFilter{}
 	if serviceType != "" {
-		filter = &store.ProviderFilter{ServiceType: &serviceType}
+		filter.ServiceType = &serviceType
+	}
+	if nameContains != "" {
+		filter.NameContains = &nameContains
+	}
+	if labels != "" {
+		selector, err := parseLabelSelector(labels)
+		if err != nil {
+			return nil, err
+		}
+		filter.LabelSelector = selector
+	}
+	if healthStatus != "" {
+		status := model.HealthStatus(healthStatus)
+		switch status {
+		case model.HealthStatusReady, model.HealthStatusNotReady, model.HealthStatusMaintenance, model.HealthStatusUnknown:
+			filter.HealthStatus = &status
+		default:
+			return nil, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid health_status %q", healthStatus)}
+		}
 	}
 
-	// Get total count for next page calculation
-	total, err := s.store.Provider().Count(ctx, filter)
+	// Fetch one extra provider to know whether there is a next page
+	result, err := s.listProviders(ctx, filter, pagination, includeCounts)
 	if err != nil {
 		return nil, err
 	}
+	if s.strictPageTokens && pageToken != "" && len(result) == 0 {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "page_token is past the end of the results, restart from the first page"}
+	}
 
-	// Fetch providers with pagination
-	pagination := &store.Pagination{Limit: pageSize, Offset: offset}
-	providers, err := s.store.Provider().List(ctx, filter, pagination)
+	// Calculate next page token from the last provider on this page
+	var nextPageToken string
+	if len(result) > pageSize {
+		result = result[:pageSize]
+		last := result[pageSize-1]
+		nextPageToken = encodePageToken(order, last)
+	}
+
+	list := &ListResult{
+		Providers:     result,
+		NextPageToken: nextPageToken,
+	}
+	if !skipTotalSize {
+		total, err := s.store.Provider().Count(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		list.TotalSize = &total
+	}
+	return list, nil
+}
+
+// listProviders fetches a page of providers as API types, joining in the instance
+// counts only when they are requested.
+func (s *ProviderService) listProviders(ctx context.Context, filter *store.ProviderFilter, pagination *store.Pagination, includeCounts bool) ([]server.Provider, error) {
+	if !includeCounts {
+		providers, err := s.store.Provider().List(ctx, filter, pagination)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]server.Provider, len(providers))
+		for i, p := range providers {
+			result[i] = *ModelToProvider(&p)
+		}
+		return result, nil
+	}
+
+	providers, err := s.store.Provider().ListWithInstanceCounts(ctx, filter, pagination)
 	if err != nil {
 		return nil, err
 	}
-
-	// Convert to API types
 	result := make([]server.Provider, len(providers))
 	for i, p := range providers {
-		result[i] = *ModelToProvider(&p)
+		result[i] = *ModelToProvider(&p.Provider)
+		result[i].InstanceCount = &p.InstanceCount
 	}
+	return result, nil
+}
 
-	// Calculate next page token
-	var nextPageToken string
-	nextOffset := offset + len(providers)
-	if int64(nextOffset) < total {
-		nextPageToken = encodePageToken(nextOffset)
+// parseOrderBy parses an order_by value, a field optionally followed by asc or desc.
+func parseOrderBy(orderBy string) (store.ProviderOrder, error) {
+	fields := strings.Fields(orderBy)
+	if len(fields) == 0 {
+		return store.DefaultProviderOrder, nil
 	}
 
-	return &ListResult{
-		Providers:     result,
-		NextPageToken: nextPageToken,
-	}, nil
+	order := store.ProviderOrder{Field: fields[0]}
+	if len(fields) > 2 || !store.ValidProviderOrderField(order.Field) {
+		return order, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid order_by %q, use name, create_time or update_time, optionally followed by asc or desc", orderBy)}
+	}
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return order, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("invalid order_by direction %q, use asc or desc", fields[1])}
+		}
+	}
+	return order, nil
 }
 
-func encodePageToken(offset int) string {
-	return base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+// orderKey identifies an order in page tokens. It is empty for the default order so
+// that tokens issued before ordering was configurable keep working.
+func orderKey(order store.ProviderOrder) string {
+	if order == store.DefaultProviderOrder {
+		return ""
+	}
+	if order.Desc {
+		return order.Field + " desc"
+	}
+	return order.Field + " asc"
 }
 
-func decodePageToken(token string) (int, error) {
-	decoded, err := base64.StdEncoding.DecodeString(token)
-	if err != nil {
-		return 0, err
+// errProviderModified is returned when the provider changed since the version the
+// update was based on.
+var errProviderModified = &ServiceError{Code: ErrCodeConflict, Message: "provider was modified concurrently"}
+
+// errPageTokenOrder is returned for a page token issued for another order.
+var errPageTokenOrder = errors.New("page token issued for a different order")
+
+// pageCursor is the JSON form of a page token: the last provider of the previous
+// page, with its value of the order field, and the order.
+type pageCursor struct {
+	OrderBy    string     `json:"order_by,omitempty"`
+	CreateTime time.Time  `json:"create_time"`
+	UpdateTime *time.Time `json:"update_time,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	ID         uuid.UUID  `json:"id"`
+}
+
+func encodePageToken(order store.ProviderOrder, last server.Provider) string {
+	cursor := pageCursor{OrderBy: orderKey(order), CreateTime: *last.CreateTime, ID: *last.Id}
+	switch order.Field {
+	case store.OrderByName:
+		cursor.Name = last.Name
+	case store.OrderByUpdateTime:
+		cursor.UpdateTime = last.UpdateTime
+	}
+	return pagination.EncodeCursor(cursor)
+}
+
+// decodePageToken returns the cursor encoded in token, or the offset when token is a
+// legacy offset token issued before cursors were introduced. The token must have
+// been issued for order.
+func decodePageToken(token string, order store.ProviderOrder) (*store.ProviderCursor, int, error) {
+	if offset, err := pagination.DecodeOffset(token); err == nil {
+		if order != store.DefaultProviderOrder {
+			return nil, 0, errPageTokenOrder
+		}
+		return nil, offset, nil
+	}
+
+	var cursor pageCursor
+	if err := pagination.DecodeCursor(token, &cursor); err != nil {
+		return nil, 0, err
+	}
+	if cursor.OrderBy != orderKey(order) {
+		return nil, 0, errPageTokenOrder
+	}
+	if cursor.ID == uuid.Nil || cursor.CreateTime.IsZero() {
+		return nil, 0, errors.New("incomplete page cursor")
+	}
+
+	after := &store.ProviderCursor{AfterValue: cursor.CreateTime, AfterID: cursor.ID}
+	switch order.Field {
+	case store.OrderByName:
+		if cursor.Name == "" {
+			return nil, 0, errors.New("incomplete page cursor")
+		}
+		after.AfterValue = cursor.Name
+	case store.OrderByUpdateTime:
+		if cursor.UpdateTime == nil {
+			return nil, 0, errors.New("incomplete page cursor")
+		}
+		after.AfterValue = *cursor.UpdateTime
 	}
-	return strconv.Atoi(string(decoded))
+	return after, 0, nil
 }
 
 // UpdateProvider updates an existing provider. Returns ErrCodeNotFound if provider
 // doesn't exist, or ErrCodeConflict if the new name is already taken.
-func (s *ProviderService) UpdateProvider(ctx context.Context, providerID string, update *server.Provider) (*server.Provider, error) {
+// With validateEndpoint set, a changed endpoint must pass a health probe before the
+// update is applied; otherwise ErrCodeProviderError is returned and the provider is
+// left unchanged.
+func (s *ProviderService) UpdateProvider(ctx context.Context, providerID string, update *server.Provider, validateEndpoint bool) (_ *server.Provider, err error) {
+	defer func() { s.recordOperation("update", err) }()
+
 	id, err := uuid.Parse(providerID)
 	if err != nil {
 		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
 	}
 
+	update, err = s.validateProvider(update)
+	if err != nil {
+		return nil, err
+	}
+
 	existing, err := s.store.Provider().Get(ctx, id)
 	if err != nil {
 		if errors.Is(err, store.ErrProviderNotFound) {
@@ -260,6 +851,12 @@ func (s *ProviderService) UpdateProvider(ctx context.Context, providerID string,
 		}
 	}
 
+	if validateEndpoint && update.Endpoint != existing.Endpoint {
+		if err := s.probeEndpoint(ctx, *existing, update); err != nil {
+			return nil, err
+		}
+	}
+
 	updated, err := s.updateExistingProvider(ctx, existing, update)
 	if err != nil {
 		return nil, err
@@ -268,13 +865,192 @@ func (s *ProviderService) UpdateProvider(ctx context.Context, providerID string,
 	return ModelToProvider(updated), nil
 }
 
-// DeleteProvider removes a provider by ID. Returns ErrCodeNotFound if not found.
-func (s *ProviderService) DeleteProvider(ctx context.Context, providerID string) error {
+// probeEndpoint sends a health probe to the endpoint of req, with the credentials
+// and health check settings candidate has once req is applied to it. Returns
+// ErrCodeProviderError if the endpoint is unreachable or unhealthy.
+func (s *ProviderService) probeEndpoint(ctx context.Context, candidate model.Provider, req *server.Provider) error {
+	candidate.Endpoint = req.Endpoint
+	setAuth(&candidate, req.Auth)
+	setHealthCheck(&candidate, req)
+	if err := healthcheck.Probe(ctx, s.probeClient, candidate); err != nil {
+		return &ServiceError{Code: ErrCodeProviderError, Message: fmt.Sprintf("endpoint %s failed health check: %v", req.Endpoint, err)}
+	}
+	return nil
+}
+
+// PatchProvider updates only the fields set in the patch and leaves the others
+// unchanged; an empty patch returns the provider as is. The set fields are
+// validated like on RegisterOrUpdateProvider. When the patch carries a version, the
+// provider must still have it. Returns ErrCodeNotFound if the provider doesn't
+// exist, or ErrCodeConflict if the new name is already taken or the provider was
+// modified since the version.
+func (s *ProviderService) PatchProvider(ctx context.Context, providerID string, patch *server.ProviderPatch) (_ *server.Provider, err error) {
+	defer func() { s.recordOperation("update", err) }()
+
+	id, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
+	}
+	if err := s.validatePatch(patch); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.Provider().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrProviderNotFound) {
+			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider %s not found", providerID)}
+		}
+		return nil, err
+	}
+	if patch.Version != nil && *patch.Version != existing.Version {
+		return nil, errProviderModified
+	}
+	fields, err := s.patchFields(*existing, patch)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return ModelToProvider(existing), nil
+	}
+	fields["update_time"] = s.clock.Now()
+
+	patched, err := s.store.Provider().Patch(ctx, id, patch.Version, fields)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrProviderNotFound):
+			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider %s not found", providerID)}
+		case errors.Is(err, store.ErrProviderModified):
+			return nil, errProviderModified
+		case errors.Is(err, store.ErrProviderNameTaken):
+			return nil, &ServiceError{Code: ErrCodeConflict, Message: fmt.Sprintf("name '%s' is already taken", *patch.Name)}
+		}
+		return nil, err
+	}
+	s.invalidateCache(id)
+
+	s.logger.Info("Patched provider", "provider_name", patched.Name, "provider_id", id)
+	return ModelToProvider(patched), nil
+}
+
+// validatePatch runs the set fields of a patch through the validators of
+// RegisterOrUpdateProvider.
+func (s *ProviderService) validatePatch(patch *server.ProviderPatch) error {
+	for field, value := range map[string]*string{
+		"name": patch.Name, "endpoint": patch.Endpoint, "service_type": patch.ServiceType, "schema_version": patch.SchemaVersion,
+	} {
+		if value != nil && *value == "" {
+			return &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("%s must not be empty", field)}
+		}
+	}
+	if err := validateMaintenanceWindow(patch.MaintenanceWindow); err != nil {
+		return err
+	}
+	if err := validateTimeout(patch.TimeoutSeconds); err != nil {
+		return err
+	}
+	if err := validateHealthPath(patch.HealthPath); err != nil {
+		return err
+	}
+	if err := validateHealthExpectedStatuses(patch.HealthExpectedStatuses); err != nil {
+		return err
+	}
+	if err := validateInstancePaths(&server.Provider{CreatePath: patch.CreatePath, GetPath: patch.GetPath, DeletePath: patch.DeletePath}); err != nil {
+		return err
+	}
+	if err := validateLabels(patch.Labels); err != nil {
+		return err
+	}
+	if patch.SchemaVersion != nil {
+		return s.validateSchemaVersion(*patch.SchemaVersion)
+	}
+	return nil
+}
+
+// patchFields applies the set fields of a patch to a copy of the provider, with the
+// setters RegisterOrUpdateProvider uses, and returns the columns they update.
+func (s *ProviderService) patchFields(provider model.Provider, patch *server.ProviderPatch) (map[string]any, error) {
+	fields := map[string]any{}
+	if patch.Name != nil {
+		fields["name"] = *patch.Name
+	}
+	if patch.Endpoint != nil {
+		req, err := s.applyEndpointPolicy(&server.Provider{Endpoint: *patch.Endpoint})
+		if err != nil {
+			return nil, err
+		}
+		fields["endpoint"] = req.Endpoint
+	}
+	if patch.ServiceType != nil {
+		fields["service_type"] = *patch.ServiceType
+	}
+	if patch.SchemaVersion != nil {
+		fields["schema_version"] = *patch.SchemaVersion
+	}
+	if patch.DebugLogging != nil {
+		fields["debug_logging"] = *patch.DebugLogging
+	}
+	if patch.TimeoutSeconds != nil {
+		setTimeout(&provider, patch.TimeoutSeconds)
+		fields["timeout_seconds"] = provider.TimeoutSeconds
+	}
+	if patch.HealthPath != nil {
+		fields["health_path"] = *patch.HealthPath
+	}
+	if patch.HealthExpectedStatuses != nil {
+		// An empty list is stored as none, as on a full update.
+		var statuses []int
+		if len(*patch.HealthExpectedStatuses) > 0 {
+			statuses = slices.Clone(*patch.HealthExpectedStatuses)
+		}
+		fields["health_expected_statuses"] = statuses
+	}
+	if patch.HealthCheckDisabled != nil {
+		fields["health_check_disabled"] = *patch.HealthCheckDisabled
+	}
+	if patch.MaintenanceWindow != nil {
+		setMaintenanceWindow(&provider, patch.MaintenanceWindow)
+		fields["maintenance_start"] = provider.MaintenanceStart
+		fields["maintenance_end"] = provider.MaintenanceEnd
+	}
+	if patch.Labels != nil {
+		setLabels(&provider, patch.Labels)
+		fields["labels"] = provider.Labels
+	}
+	for column, path := range map[string]*string{
+		"create_path": patch.CreatePath, "get_path": patch.GetPath, "delete_path": patch.DeletePath,
+	} {
+		if path != nil {
+			fields[column] = *path
+		}
+	}
+	if patch.Auth != nil {
+		setAuth(&provider, patch.Auth)
+		if err := validateAuth(&provider); err != nil {
+			return nil, err
+		}
+		fields["auth_type"] = provider.AuthType
+		fields["auth_header"] = provider.AuthHeader
+		fields["auth_token"] = provider.AuthToken
+	}
+	return fields, nil
+}
+
+// DeleteProvider soft deletes a provider by ID; see RestoreProvider. Returns
+// ErrCodeNotFound if not found, or ErrCodeConflict if the provider still has instances
+// and force is not set.
+func (s *ProviderService) DeleteProvider(ctx context.Context, providerID string, force bool) (err error) {
+	defer func() { s.recordOperation("delete", err) }()
+
 	id, err := uuid.Parse(providerID)
 	if err != nil {
 		return &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
 	}
 
+	_, _, err = s.checkDelete(ctx, id, force)
+	if err != nil {
+		return err
+	}
+
 	err = s.store.Provider().Delete(ctx, id)
 	if err != nil {
 		if errors.Is(err, store.ErrProviderNotFound) {
@@ -282,7 +1058,89 @@ func (s *ProviderService) DeleteProvider(ctx context.Context, providerID string)
 		}
 		return err
 	}
+	s.invalidateCache(id)
 
-	log.Printf("Deleted provider: %s", providerID)
+	s.logger.Info("Deleted provider", "provider_id", id, "force", force)
 	return nil
 }
+
+// PreviewDeleteProvider runs the checks of DeleteProvider without deleting
+// anything, and returns the provider that would be deleted with its number of
+// instances. It fails with the same errors as DeleteProvider would.
+func (s *ProviderService) PreviewDeleteProvider(ctx context.Context, providerID string, force bool) (*server.ProviderDeletePreview, error) {
+	id, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
+	}
+
+	existing, count, err := s.checkDelete(ctx, id, force)
+	if err != nil {
+		return nil, err
+	}
+	return &server.ProviderDeletePreview{Provider: *ModelToProvider(existing), InstanceCount: count}, nil
+}
+
+// checkDelete looks up the provider to delete and counts its instances. Without
+// force, a provider that still has instances cannot be deleted.
+func (s *ProviderService) checkDelete(ctx context.Context, id uuid.UUID, force bool) (*model.Provider, int64, error) {
+	// Instances and the state registries are keyed by name, so look it up before
+	// deleting.
+	existing, err := s.store.Provider().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrProviderNotFound) {
+			return nil, 0, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider %s not found", id)}
+		}
+		return nil, 0, err
+	}
+
+	count, err := s.store.ServiceTypeInstance().CountByProvider(ctx, existing.Name)
+	if err != nil {
+		return nil, 0, err
+	}
+	if count > 0 && !force {
+		return nil, 0, &ServiceError{Code: ErrCodeConflict, Message: fmt.Sprintf("provider has %d active instances", count)}
+	}
+	return existing, count, nil
+}
+
+// RestoreProvider brings back a deleted provider. Returns ErrCodeNotFound if no
+// deleted provider has the ID, or ErrCodeConflict if its name has been taken since.
+func (s *ProviderService) RestoreProvider(ctx context.Context, providerID string) (_ *server.Provider, err error) {
+	defer func() { s.recordOperation("restore", err) }()
+
+	id, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
+	}
+
+	restored, err := s.store.Provider().Restore(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrProviderNotFound):
+			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("deleted provider %s not found", providerID)}
+		case errors.Is(err, store.ErrProviderNameTaken):
+			return nil, &ServiceError{Code: ErrCodeConflict, Message: "the provider name is taken by another provider"}
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Restored provider", "provider_id", id, "provider_name", restored.Name)
+	return ModelToProvider(restored), nil
+}
+
+// recordOperation counts a provider operation by its result, "ok" or the service
+// error code.
+func (s *ProviderService) recordOperation(operation string, err error) {
+	if s.metrics == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+		var svcErr *ServiceError
+		if errors.As(err, &svcErr) {
+			result = svcErr.Code
+		}
+	}
+	s.metrics.ProviderOperations.WithLabelValues(operation, result).Inc()
+}
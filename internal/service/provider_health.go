@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
+	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	maxHealthCheckBatch    = 100
+	healthCheckConcurrency = 8
+
+	defaultHealthEventLimit = 20
+	maxHealthEventLimit     = 100
+)
+
+// ProviderHealthChecker checks a single provider and stores its new health status.
+// It is implemented by the health monitor.
+type ProviderHealthChecker interface {
+	CheckProvider(ctx context.Context, provider model.Provider) (healthcheck.CheckResult, error)
+}
+
+// WithHealthChecker enables on-demand health checks through CheckProvidersHealth.
+func WithHealthChecker(checker ProviderHealthChecker) ProviderServiceOption {
+	return func(s *ProviderService) {
+		s.healthChecker = checker
+	}
+}
+
+// CheckProvidersHealth rechecks the given providers, a few at a time, and returns one
+// result per ID in request order. A provider that cannot be checked, for example
+// because it does not exist, gets a result with only an error.
+func (s *ProviderService) CheckProvidersHealth(ctx context.Context, ids []openapi_types.UUID) (*server.ProviderHealthCheckResults, error) {
+	if len(ids) == 0 || len(ids) > maxHealthCheckBatch {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: fmt.Sprintf("between 1 and %d provider IDs are required", maxHealthCheckBatch)}
+	}
+	if s.healthChecker == nil {
+		return nil, errors.New("provider health checks are not configured")
+	}
+
+	results := make([]server.ProviderHealthCheckResult, len(ids))
+	sem := make(chan struct{}, healthCheckConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = s.checkProviderHealth(ctx, uuid.UUID(id))
+		}()
+	}
+	wg.Wait()
+
+	return &server.ProviderHealthCheckResults{Results: results}, nil
+}
+
+// CheckProviderHealth rechecks a single provider right away and returns the outcome.
+// Returns ErrCodeNotFound if the provider doesn't exist.
+func (s *ProviderService) CheckProviderHealth(ctx context.Context, providerID string) (*server.ProviderHealthCheckResult, error) {
+	id, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
+	}
+	if s.healthChecker == nil {
+		return nil, errors.New("provider health checks are not configured")
+	}
+
+	provider, err := s.store.Provider().Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrProviderNotFound) {
+			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider %s not found", providerID)}
+		}
+		return nil, err
+	}
+
+	result := s.runHealthCheck(ctx, *provider)
+	return &result, nil
+}
+
+func (s *ProviderService) checkProviderHealth(ctx context.Context, id uuid.UUID) server.ProviderHealthCheckResult {
+	provider, err := s.store.Provider().Get(ctx, id)
+	if err != nil {
+		detail := err.Error()
+		return server.ProviderHealthCheckResult{Id: openapi_types.UUID(id), Error: &detail}
+	}
+	return s.runHealthCheck(ctx, *provider)
+}
+
+// runHealthCheck checks the provider through the health checker, which stores the
+// new health status, and reports the outcome.
+func (s *ProviderService) runHealthCheck(ctx context.Context, provider model.Provider) server.ProviderHealthCheckResult {
+	result := server.ProviderHealthCheckResult{Id: openapi_types.UUID(provider.ID)}
+
+	check, err := s.healthChecker.CheckProvider(ctx, provider)
+	s.invalidateCache(provider.ID)
+	if err != nil {
+		detail := err.Error()
+		result.Error = &detail
+		return result
+	}
+
+	healthy := check.Err == nil
+	result.Healthy = &healthy
+	result.HealthStatus = check.Status.StringPtr()
+	if check.Err != nil {
+		detail := check.Err.Error()
+		result.Error = &detail
+	}
+	return result
+}
+
+// ListHealthEvents returns up to limit of the provider's most recent health checks,
+// newest first. A limit of 0 uses the default and larger limits are capped.
+// Returns ErrCodeNotFound if the provider doesn't exist.
+func (s *ProviderService) ListHealthEvents(ctx context.Context, providerID string, limit int) (*server.HealthEventList, error) {
+	id, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "invalid provider ID format"}
+	}
+	if limit < 0 {
+		return nil, &ServiceError{Code: ErrCodeValidation, Message: "limit must not be negative"}
+	}
+	if limit == 0 {
+		limit = defaultHealthEventLimit
+	}
+	limit = min(limit, maxHealthEventLimit)
+
+	if _, err := s.store.Provider().Get(ctx, id); err != nil {
+		if errors.Is(err, store.ErrProviderNotFound) {
+			return nil, &ServiceError{Code: ErrCodeNotFound, Message: fmt.Sprintf("provider %s not found", providerID)}
+		}
+		return nil, err
+	}
+
+	events, err := s.store.Provider().ListHealthEvents(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	list := &server.HealthEventList{Events: make([]server.HealthEvent, len(events))}
+	for i := range events {
+		list.Events[i] = ModelToHealthEvent(&events[i])
+	}
+	return list, nil
+}
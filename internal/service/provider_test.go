@@ -2,9 +2,22 @@ package service_test
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/api/server"
+	"github.com/dcm-project/service-provider-manager/internal/clock"
+	"github.com/dcm-project/service-provider-manager/internal/config"
+	"github.com/dcm-project/service-provider-manager/internal/healthcheck"
+	"github.com/dcm-project/service-provider-manager/internal/metrics"
+	"github.com/dcm-project/service-provider-manager/internal/netpolicy"
+	"github.com/dcm-project/service-provider-manager/internal/pagination"
 	"github.com/dcm-project/service-provider-manager/internal/service"
 	"github.com/dcm-project/service-provider-manager/internal/store"
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
@@ -12,6 +25,7 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -28,10 +42,11 @@ var _ = Describe("ProviderService", func() {
 	BeforeEach(func() {
 		var err error
 		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Silent),
+			Logger:         logger.Default.LogMode(logger.Silent),
+			TranslateError: true,
 		})
 		Expect(err).NotTo(HaveOccurred())
-		Expect(db.AutoMigrate(&model.Provider{})).To(Succeed())
+		Expect(db.AutoMigrate(&model.Provider{}, &model.ServiceTypeInstance{}, &model.HealthEvent{})).To(Succeed())
 
 		dataStore = store.NewStore(db)
 		providerService = service.NewProviderService(dataStore)
@@ -46,7 +61,7 @@ var _ = Describe("ProviderService", func() {
 		It("creates a new provider", func() {
 			req := newProvider("new-provider")
 
-			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp.Status).NotTo(BeNil())
@@ -54,14 +69,93 @@ var _ = Describe("ProviderService", func() {
 			Expect(resp.Name).To(Equal("new-provider"))
 		})
 
+		It("registers a new provider as unknown until it is checked", func() {
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("unchecked-provider"), nil, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.HealthStatus).To(Equal(model.HealthStatusUnknown.StringPtr()))
+
+			result, err := providerService.ListProviders(ctx, "", "ready", "", "", 0, "", "", false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(BeEmpty())
+		})
+
+		It("registers a provider without health checks as ready", func() {
+			req := newProvider("static-provider")
+			disabled := true
+			req.HealthCheckDisabled = &disabled
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.HealthStatus).To(Equal(model.HealthStatusReady.StringPtr()))
+		})
+
+		Context("with endpoint validation", func() {
+			var healthy *httptest.Server
+
+			BeforeEach(func() {
+				healthy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+				DeferCleanup(healthy.Close)
+			})
+
+			It("registers a provider whose endpoint is reachable as ready", func() {
+				req := newProvider("probed-provider")
+				req.Endpoint = healthy.URL
+
+				resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, true)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*resp.Status).To(Equal(server.Registered))
+				Expect(resp.HealthStatus).To(Equal(model.HealthStatusReady.StringPtr()))
+			})
+
+			It("rejects an unreachable endpoint without registering the provider", func() {
+				unreachable := httptest.NewServer(http.NotFoundHandler())
+				unreachable.Close()
+				req := newProvider("unreachable-provider")
+				req.Endpoint = unreachable.URL
+
+				_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, true)
+
+				Expect(err).To(HaveOccurred())
+				svcErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(svcErr.Code).To(Equal(service.ErrCodeProviderError))
+
+				_, err = providerService.GetProviderByName(ctx, "unreachable-provider")
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("probes a changed endpoint on re-registration", func() {
+				req := newProvider("re-registered-provider")
+				_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				unreachable := httptest.NewServer(http.NotFoundHandler())
+				unreachable.Close()
+				req.Endpoint = unreachable.URL
+				_, err = providerService.RegisterOrUpdateProvider(ctx, req, nil, true)
+				Expect(err).To(HaveOccurred())
+
+				req.Endpoint = healthy.URL
+				resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*resp.Status).To(Equal(server.Updated))
+				Expect(resp.Endpoint).To(Equal(healthy.URL))
+			})
+		})
+
 		It("updates existing provider with same name and ID", func() {
 			req := newProvider("update-test")
-			resp1, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			resp1, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			// Re-register with same ID
 			req.Id = resp1.Id
 			req.Endpoint = "https://updated.example.com"
-			resp2, err := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			resp2, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp2.Status).NotTo(BeNil())
@@ -70,12 +164,12 @@ var _ = Describe("ProviderService", func() {
 
 		It("updates existing provider with same name and no ID (idempotent)", func() {
 			req := newProvider("idempotent-test")
-			resp1, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			resp1, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			// Re-register with same name but NO ID
 			req2 := newProvider("idempotent-test")
 			req2.Endpoint = "https://updated.example.com"
-			resp2, err := providerService.RegisterOrUpdateProvider(ctx, req2, nil)
+			resp2, err := providerService.RegisterOrUpdateProvider(ctx, req2, nil, false)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp2.Status).NotTo(BeNil())
@@ -86,12 +180,12 @@ var _ = Describe("ProviderService", func() {
 
 		It("returns conflict when name exists with different ID", func() {
 			req := newProvider("conflict-name")
-			providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			// Try with different ID
 			newID := openapi_types.UUID(uuid.New())
 			req.Id = &newID
-			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).To(HaveOccurred())
 			svcErr, ok := err.(*service.ServiceError)
@@ -101,114 +195,303 @@ var _ = Describe("ProviderService", func() {
 
 		It("returns conflict when providerID exists with different name", func() {
 			req := newProvider("first-name")
-			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			// Try with same ID but different name
 			req2 := newProvider("second-name")
 			req2.Id = resp.Id
-			_, err := providerService.RegisterOrUpdateProvider(ctx, req2, nil)
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req2, nil, false)
 
 			Expect(err).To(HaveOccurred())
 			svcErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
 			Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
 		})
-	})
 
-	Describe("GetProvider", func() {
-		It("returns the provider", func() {
-			req := newProvider("get-test")
-			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+		It("rejects exactly one of two concurrent registrations of a name with different IDs", func() {
+			sqlDB, _ := db.DB()
+			sqlDB.SetMaxOpenConns(1)
 
-			provider, err := providerService.GetProvider(ctx, resp.Id.String())
+			racing := &racingStore{Store: dataStore, provider: &racingProviderStore{Provider: dataStore.Provider()}}
+			racing.provider.lookups.Add(2)
+			racingService := service.NewProviderService(racing)
+
+			var wg sync.WaitGroup
+			errs := make([]error, 2)
+			for i := range 2 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					req := newProvider("racing-ids")
+					id := openapi_types.UUID(uuid.New())
+					req.Id = &id
+					_, errs[i] = racingService.RegisterOrUpdateProvider(ctx, req, nil, false)
+				}()
+			}
+			wg.Wait()
+
+			var conflicts int
+			for _, err := range errs {
+				if err != nil {
+					Expect(err).To(BeAssignableToTypeOf(&service.ServiceError{}))
+					Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeConflict))
+					conflicts++
+				}
+			}
+			Expect(conflicts).To(Equal(1))
 
+			count, err := dataStore.Provider().Count(ctx, nil)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(provider.Name).To(Equal("get-test"))
+			Expect(count).To(Equal(int64(1)))
 		})
 
-		It("returns error for non-existent provider", func() {
-			_, err := providerService.GetProvider(ctx, uuid.New().String())
+		It("resolves concurrent registrations of the same new name idempotently", func() {
+			// A single connection keeps both goroutines on the same in-memory database.
+			sqlDB, _ := db.DB()
+			sqlDB.SetMaxOpenConns(1)
+
+			racing := &racingStore{Store: dataStore, provider: &racingProviderStore{Provider: dataStore.Provider()}}
+			racing.provider.lookups.Add(2)
+			racingService := service.NewProviderService(racing)
+
+			var wg sync.WaitGroup
+			responses := make([]*server.Provider, 2)
+			errs := make([]error, 2)
+			for i := range 2 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					responses[i], errs[i] = racingService.RegisterOrUpdateProvider(ctx, newProvider("racing-provider"), nil, false)
+				}()
+			}
+			wg.Wait()
+
+			Expect(errs).To(HaveEach(BeNil()))
+			statuses := []server.ProviderStatus{*responses[0].Status, *responses[1].Status}
+			Expect(statuses).To(ConsistOf(server.Registered, server.Updated))
+			Expect(responses[0].Id).To(Equal(responses[1].Id))
+
+			count, err := dataStore.Provider().Count(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+	})
+
+	Describe("endpoint scheme policy", func() {
+		httpProvider := func() *server.Provider {
+			req := newProvider("http-provider")
+			req.Endpoint = "http://example.com/api"
+			return req
+		}
+
+		It("rejects http endpoints with require-https", func() {
+			svc := service.NewProviderService(dataStore, service.WithEndpointSchemePolicy(service.EndpointSchemeRequireHTTPS))
+
+			_, err := svc.RegisterOrUpdateProvider(ctx, httpProvider(), nil, false)
 
 			Expect(err).To(HaveOccurred())
 			svcErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
-			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+			Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
 		})
-	})
 
-	Describe("ListProviders", func() {
-		It("returns all providers", func() {
-			providerService.RegisterOrUpdateProvider(ctx, newProvider("p1"), nil)
-			providerService.RegisterOrUpdateProvider(ctx, newProvider("p2"), nil)
+		It("rewrites http endpoints to https with upgrade-http", func() {
+			svc := service.NewProviderService(dataStore, service.WithEndpointSchemePolicy(service.EndpointSchemeUpgradeHTTP))
+			req := httpProvider()
 
-			result, err := providerService.ListProviders(ctx, "", 0, "")
+			resp, err := svc.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.Providers).To(HaveLen(2))
+			Expect(resp.Endpoint).To(Equal("https://example.com/api"))
+			Expect(req.Endpoint).To(Equal("http://example.com/api"))
 		})
 
-		It("filters by service type", func() {
-			req1 := newProvider("vm-provider")
-			req1.ServiceType = "vm"
-			providerService.RegisterOrUpdateProvider(ctx, req1, nil)
+		It("applies the policy on update", func() {
+			svc := service.NewProviderService(dataStore, service.WithEndpointSchemePolicy(service.EndpointSchemeUpgradeHTTP))
+			resp, err := svc.RegisterOrUpdateProvider(ctx, newProvider("http-provider"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
 
-			req2 := newProvider("container-provider")
-			req2.ServiceType = "container"
-			providerService.RegisterOrUpdateProvider(ctx, req2, nil)
+			updated, err := svc.UpdateProvider(ctx, resp.Id.String(), httpProvider(), false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Endpoint).To(Equal("https://example.com/api"))
+		})
 
-			result, err := providerService.ListProviders(ctx, "vm", 0, "")
+		It("keeps http endpoints with allow-http", func() {
+			svc := service.NewProviderService(dataStore, service.WithEndpointSchemePolicy(service.EndpointSchemeAllowHTTP))
+
+			resp, err := svc.RegisterOrUpdateProvider(ctx, httpProvider(), nil, false)
 
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.Providers).To(HaveLen(1))
+			Expect(resp.Endpoint).To(Equal("http://example.com/api"))
 		})
 
-		It("returns error for negative page size", func() {
-			_, err := providerService.ListProviders(ctx, "", -1, "")
+		It("rejects unknown policy names", func() {
+			_, err := service.ParseEndpointSchemePolicy("https-please")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("provider host allowlist", func() {
+		var allowlisted *service.ProviderService
+
+		BeforeEach(func() {
+			allowlist := netpolicy.NewHostAllowlist([]string{"providers.example.com"})
+			allowlisted = service.NewProviderService(dataStore, service.WithHostAllowlist(allowlist))
+		})
+
+		It("registers a provider on an allow-listed host", func() {
+			req := newProvider("allowed")
+			req.Endpoint = "https://kubevirt.providers.example.com/api"
+
+			_, err := allowlisted.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a provider on a host that is not listed", func() {
+			req := newProvider("rejected")
+			req.Endpoint = "https://attacker.example.org/api"
+
+			_, err := allowlisted.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).To(HaveOccurred())
 			svcErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
 			Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
 		})
+	})
 
-		It("coerces page size to max", func() {
-			for i := 0; i < 5; i++ {
-				providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("coerce-p%d", i)), nil)
-			}
+	Describe("CheckProvidersHealth", func() {
+		var (
+			checkingService *service.ProviderService
+			healthy         *httptest.Server
+			unhealthy       *httptest.Server
+		)
 
-			result, err := providerService.ListProviders(ctx, "", 2, "")
+		register := func(name, endpoint string) openapi_types.UUID {
+			req := newProvider(name)
+			req.Endpoint = endpoint
+			resp, err := checkingService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			return *resp.Id
+		}
 
+		BeforeEach(func() {
+			// The checks run concurrently and every sqlite :memory: connection is a
+			// separate database.
+			sqlDB, err := db.DB()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.Providers).To(HaveLen(2))
-			Expect(result.NextPageToken).NotTo(BeEmpty())
+			sqlDB.SetMaxOpenConns(1)
+
+			healthy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			unhealthy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+
+			monitor := healthcheck.NewMonitor(dataStore.Provider(), &config.HealthCheckConfig{
+				Interval:               10 * time.Second,
+				Timeout:                time.Second,
+				MaxConsecutiveFailures: 1,
+				BaseBackoffInterval:    10 * time.Second,
+				MaxBackoffInterval:     time.Minute,
+				HistorySize:            10,
+			})
+			checkingService = service.NewProviderService(dataStore, service.WithHealthChecker(monitor))
 		})
 
-		It("paginates through results", func() {
-			for i := 0; i < 5; i++ {
-				providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("paginate-p%d", i)), nil)
-			}
+		AfterEach(func() {
+			healthy.Close()
+			unhealthy.Close()
+		})
+
+		It("checks every provider and stores the outcome", func() {
+			healthyID := register("healthy-provider", healthy.URL)
+			unhealthyID := register("unhealthy-provider", unhealthy.URL)
+			unknownID := openapi_types.UUID(uuid.New())
+
+			results, err := checkingService.CheckProvidersHealth(ctx, []openapi_types.UUID{healthyID, unhealthyID, unknownID})
 
-			// First page
-			result1, err := providerService.ListProviders(ctx, "", 2, "")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result1.Providers).To(HaveLen(2))
-			Expect(result1.NextPageToken).NotTo(BeEmpty())
+			Expect(results.Results).To(HaveLen(3))
 
-			// Second page
-			result2, err := providerService.ListProviders(ctx, "", 2, result1.NextPageToken)
+			Expect(results.Results[0].Id).To(Equal(healthyID))
+			Expect(*results.Results[0].Healthy).To(BeTrue())
+			Expect(*results.Results[0].HealthStatus).To(Equal("ready"))
+			Expect(results.Results[0].Error).To(BeNil())
+
+			Expect(results.Results[1].Id).To(Equal(unhealthyID))
+			Expect(*results.Results[1].Healthy).To(BeFalse())
+			Expect(*results.Results[1].HealthStatus).To(Equal("not_ready"))
+			Expect(*results.Results[1].Error).To(ContainSubstring("503"))
+
+			Expect(results.Results[2].Id).To(Equal(unknownID))
+			Expect(results.Results[2].Healthy).To(BeNil())
+			Expect(results.Results[2].Error).NotTo(BeNil())
+
+			stored, err := checkingService.GetProvider(ctx, unhealthyID.String())
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result2.Providers).To(HaveLen(2))
-			Expect(result2.NextPageToken).NotTo(BeEmpty())
+			Expect(*stored.HealthStatus).To(Equal("not_ready"))
+		})
 
-			// Third page (last)
-			result3, err := providerService.ListProviders(ctx, "", 2, result2.NextPageToken)
+		It("checks a single provider and returns its new status", func() {
+			healthyID := register("healthy-single", healthy.URL)
+			unhealthyID := register("unhealthy-single", unhealthy.URL)
+
+			result, err := checkingService.CheckProviderHealth(ctx, healthyID.String())
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result3.Providers).To(HaveLen(1))
-			Expect(result3.NextPageToken).To(BeEmpty())
+			Expect(*result.Healthy).To(BeTrue())
+			Expect(*result.HealthStatus).To(Equal("ready"))
+
+			result, err = checkingService.CheckProviderHealth(ctx, unhealthyID.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Id).To(Equal(unhealthyID))
+			Expect(*result.Healthy).To(BeFalse())
+			Expect(*result.HealthStatus).To(Equal("not_ready"))
+			Expect(*result.Error).NotTo(BeEmpty())
+
+			stored, err := checkingService.GetProvider(ctx, unhealthyID.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*stored.HealthStatus).To(Equal("not_ready"))
 		})
 
-		It("returns error for invalid page token", func() {
-			_, err := providerService.ListProviders(ctx, "", 0, "invalid-token")
+		It("lists the health checks of a provider, newest first", func() {
+			id := register("history-provider", unhealthy.URL)
+			for range 2 {
+				_, err := checkingService.CheckProviderHealth(ctx, id.String())
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			events, err := checkingService.ListHealthEvents(ctx, id.String(), 0)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events.Events).To(HaveLen(2))
+			Expect(events.Events[0].HealthStatus).To(Equal("not_ready"))
+			Expect(*events.Events[0].HttpStatus).To(Equal(http.StatusServiceUnavailable))
+			Expect(*events.Events[0].Error).To(Equal("status code 503"))
+			Expect(events.Events[0].Time).NotTo(BeTemporally("<", events.Events[1].Time))
+		})
+
+		It("returns not found for the health events of an unknown provider", func() {
+			_, err := checkingService.ListHealthEvents(ctx, uuid.NewString(), 0)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeNotFound))
+		})
+
+		It("returns not found when checking an unknown provider", func() {
+			_, err := checkingService.CheckProviderHealth(ctx, uuid.NewString())
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeNotFound))
+		})
+
+		It("rejects an empty batch", func() {
+			_, err := checkingService.CheckProvidersHealth(ctx, nil)
 
 			Expect(err).To(HaveOccurred())
 			svcErr, ok := err.(*service.ServiceError)
@@ -217,89 +500,1575 @@ var _ = Describe("ProviderService", func() {
 		})
 	})
 
-	Describe("UpdateProvider", func() {
-		It("updates the provider", func() {
-			req := newProvider("update-provider")
-			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+	Describe("with a fake clock", func() {
+		var (
+			fakeClock    *clock.Fake
+			start        time.Time
+			clockService *service.ProviderService
+		)
 
-			update := &server.Provider{
-				Id:            resp.Id,
-				Name:          "update-provider",
-				Endpoint:      "https://updated.example.com",
-				ServiceType:   "vm",
-				SchemaVersion: "v1alpha1",
-			}
+		BeforeEach(func() {
+			start = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			fakeClock = clock.NewFake(start)
 
-			updated, err := providerService.UpdateProvider(ctx, resp.Id.String(), update)
+			// gorm stamps update_time itself, so it has to share the clock.
+			db.Config.NowFunc = fakeClock.Now
+			clockService = service.NewProviderService(dataStore,
+				service.WithClock(fakeClock),
+				service.WithResponseCache(10, time.Minute),
+			)
+		})
 
+		It("sets create and update times from the clock", func() {
+			resp, err := clockService.RegisterOrUpdateProvider(ctx, newProvider("clocked"), nil, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(updated.Endpoint).To(Equal("https://updated.example.com"))
+			Expect(resp.CreateTime.Equal(start)).To(BeTrue())
+
+			fakeClock.Advance(time.Hour)
+			updated, err := clockService.UpdateProvider(ctx, resp.Id.String(), newProvider("clocked"), false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.UpdateTime.Equal(start.Add(time.Hour))).To(BeTrue())
 		})
 
-		It("returns conflict when renaming to existing name", func() {
-			// Create two providers
-			providerService.RegisterOrUpdateProvider(ctx, newProvider("original-name"), nil)
-			resp2, _ := providerService.RegisterOrUpdateProvider(ctx, newProvider("to-rename"), nil)
+		It("expires cached providers after the TTL", func() {
+			counting := &countingStore{Store: dataStore, provider: &countingProviderStore{Provider: dataStore.Provider()}}
+			cachedService := service.NewProviderService(counting,
+				service.WithClock(fakeClock),
+				service.WithResponseCache(10, time.Minute),
+			)
+			resp, err := cachedService.RegisterOrUpdateProvider(ctx, newProvider("clocked"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
 
-			// Try to rename second provider to first provider's name
-			update := &server.Provider{
-				Name:          "original-name",
-				Endpoint:      "https://example.com",
-				ServiceType:   "vm",
-				SchemaVersion: "v1alpha1",
-			}
+			_, err = cachedService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock.Advance(time.Minute)
+			_, err = cachedService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counting.provider.gets.Load()).To(BeEquivalentTo(1))
+
+			fakeClock.Advance(time.Second)
+			_, err = cachedService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counting.provider.gets.Load()).To(BeEquivalentTo(2))
+		})
+	})
+
+	Describe("maintenance window", func() {
+		window := func(start time.Time, length time.Duration) *server.MaintenanceWindow {
+			return &server.MaintenanceWindow{StartTime: start, EndTime: start.Add(length)}
+		}
+
+		It("stores the window and clears it when it is omitted on update", func() {
+			start := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+			req := newProvider("maintained")
+			req.MaintenanceWindow = window(start, 2*time.Hour)
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.MaintenanceWindow).NotTo(BeNil())
+			Expect(resp.MaintenanceWindow.EndTime.Equal(start.Add(2 * time.Hour))).To(BeTrue())
+
+			updated, err := providerService.UpdateProvider(ctx, resp.Id.String(), newProvider("maintained"), false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.MaintenanceWindow).To(BeNil())
+		})
 
-			_, err := providerService.UpdateProvider(ctx, resp2.Id.String(), update)
+		It("rejects a window that ends before it starts", func() {
+			req := newProvider("maintained")
+			req.MaintenanceWindow = window(time.Now(), -time.Hour)
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).To(HaveOccurred())
 			svcErr, ok := err.(*service.ServiceError)
 			Expect(ok).To(BeTrue())
-			Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
+			Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
 		})
+	})
 
-		It("returns error for non-existent provider", func() {
-			update := &server.Provider{
-				Name:          "test",
-				Endpoint:      "https://example.com",
-				ServiceType:   "vm",
-				SchemaVersion: "v1alpha1",
+	Describe("provider credentials", func() {
+		auth := func(authType server.ProviderAuthType, token string) *server.ProviderAuth {
+			a := &server.ProviderAuth{Type: authType}
+			if token != "" {
+				a.Token = &token
 			}
+			return a
+		}
+
+		It("stores the credentials without returning the token", func() {
+			req := newProvider("authed")
+			req.Auth = auth(server.Bearer, "t0ken")
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Auth).NotTo(BeNil())
+			Expect(resp.Auth.Type).To(Equal(server.Bearer))
+			Expect(resp.Auth.Token).To(BeNil())
+
+			stored, err := dataStore.Provider().Get(ctx, uuid.UUID(*resp.Id))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.AuthToken).To(Equal("t0ken"))
+		})
+
+		It("keeps the stored token when an update omits it", func() {
+			req := newProvider("authed")
+			req.Auth = auth(server.Bearer, "t0ken")
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			update := newProvider("authed")
+			update.Auth = auth(server.Bearer, "")
+			_, err = providerService.UpdateProvider(ctx, resp.Id.String(), update, false)
+			Expect(err).NotTo(HaveOccurred())
 
-			_, err := providerService.UpdateProvider(ctx, uuid.New().String(), update)
+			stored, err := dataStore.Provider().Get(ctx, uuid.UUID(*resp.Id))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.AuthToken).To(Equal("t0ken"))
+		})
 
+		It("rejects credentials without a token or header name", func() {
+			req := newProvider("authed")
+			req.Auth = auth(server.Bearer, "")
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 			Expect(err).To(HaveOccurred())
-			svcErr, ok := err.(*service.ServiceError)
-			Expect(ok).To(BeTrue())
-			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+
+			req.Auth = auth(server.Header, "t0ken")
+			_, err = providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
 		})
 	})
 
-	Describe("DeleteProvider", func() {
-		It("deletes the provider", func() {
-			req := newProvider("to-delete")
-			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil)
+	Describe("timeout override", func() {
+		It("clamps the timeout to the maximum", func() {
+			seconds := 100000
+			req := newProvider("slow")
+			req.TimeoutSeconds = &seconds
 
-			err := providerService.DeleteProvider(ctx, resp.Id.String())
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).NotTo(HaveOccurred())
+			Expect(*resp.TimeoutSeconds).To(Equal(300))
 		})
 
-		It("returns error for non-existent provider", func() {
-			err := providerService.DeleteProvider(ctx, uuid.New().String())
+		It("rejects a negative timeout", func() {
+			seconds := -1
+			req := newProvider("slow")
+			req.TimeoutSeconds = &seconds
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
 
 			Expect(err).To(HaveOccurred())
-			svcErr, ok := err.(*service.ServiceError)
-			Expect(ok).To(BeTrue())
-			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
 		})
 	})
-})
 
-func newProvider(name string) *server.Provider {
-	return &server.Provider{
-		Name:          name,
-		Endpoint:      "https://example.com/api",
-		ServiceType:   "vm",
-		SchemaVersion: "v1alpha1",
-	}
+	Describe("health check settings", func() {
+		It("defaults the health path and keeps an empty one", func() {
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("default-path"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*resp.HealthPath).To(Equal("/health"))
+
+			empty := ""
+			req := newProvider("root-path")
+			req.HealthPath = &empty
+			resp, err = providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			got, err := providerService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.HealthPath).To(BeEmpty())
+		})
+
+		It("rejects a health path that is not a plain path", func() {
+			path := "/health?verbose=1"
+			req := newProvider("query-path")
+			req.HealthPath = &path
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("query-path"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = providerService.UpdateProvider(ctx, resp.Id.String(), req, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("stores the expected statuses and the opt-out", func() {
+			disabled := true
+			req := newProvider("static")
+			req.HealthExpectedStatuses = &[]int{200, 204}
+			req.HealthCheckDisabled = &disabled
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			got, err := providerService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.HealthExpectedStatuses).To(Equal([]int{200, 204}))
+			Expect(*got.HealthCheckDisabled).To(BeTrue())
+		})
+
+		It("rejects an invalid expected status", func() {
+			req := newProvider("bad-status")
+			req.HealthExpectedStatuses = &[]int{2000}
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+	})
+
+	Describe("instance paths", func() {
+		It("stores the paths of the provider's instance API", func() {
+			createPath, instancePath := "/v1/vms", "/v1/vms/{id}"
+			req := newProvider("vms")
+			req.CreatePath = &createPath
+			req.GetPath = &instancePath
+			req.DeletePath = &instancePath
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			got, err := providerService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.CreatePath).To(Equal("/v1/vms"))
+			Expect(*got.GetPath).To(Equal("/v1/vms/{id}"))
+			Expect(*got.DeletePath).To(Equal("/v1/vms/{id}"))
+		})
+
+		It("omits the paths that are not set", func() {
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("default-paths"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.CreatePath).To(BeNil())
+			Expect(resp.GetPath).To(BeNil())
+			Expect(resp.DeletePath).To(BeNil())
+		})
+
+		It("rejects a get path without the instance ID", func() {
+			path := "/v1/vms"
+			req := newProvider("no-id")
+			req.GetPath = &path
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("rejects a create path with the instance ID", func() {
+			path := "/v1/vms/{id}"
+			req := newProvider("create-id")
+			req.CreatePath = &path
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("rejects an unsupported schema version", func() {
+			req := newProvider("typo-version")
+			req.SchemaVersion = "v1apha1"
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+			Expect(err.Error()).To(ContainSubstring("v1alpha1"))
+			_, err = dataStore.Provider().GetByName(ctx, "typo-version")
+			Expect(err).To(MatchError(store.ErrProviderNotFound))
+		})
+
+		It("accepts the configured schema versions", func() {
+			providerService = service.NewProviderService(dataStore, service.WithSchemaVersions("v1alpha1", "v1beta1"))
+			req := newProvider("beta-version")
+			req.SchemaVersion = "v1beta1"
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providerService.SchemaVersions()).To(Equal([]string{"v1alpha1", "v1beta1"}))
+		})
+
+		It("rejects a label that cannot be used in a selector", func() {
+			labels := map[string]string{"team": "infra,apps"}
+			req := newProvider("bad-label")
+			req.Labels = &labels
+
+			_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+	})
+
+	Describe("with metrics", func() {
+		It("counts provider operations by result", func() {
+			m := metrics.New(metrics.NewRegistry())
+			instrumented := service.NewProviderService(dataStore, service.WithMetrics(m))
+
+			resp, err := instrumented.RegisterOrUpdateProvider(ctx, newProvider("metered"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = instrumented.RegisterOrUpdateProvider(ctx, newProvider("metered"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instrumented.DeleteProvider(ctx, resp.Id.String(), false)).To(Succeed())
+			Expect(instrumented.DeleteProvider(ctx, resp.Id.String(), false)).NotTo(Succeed())
+
+			Expect(testutil.ToFloat64(m.ProviderOperations.WithLabelValues("create", "ok"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(m.ProviderOperations.WithLabelValues("update", "ok"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(m.ProviderOperations.WithLabelValues("delete", "ok"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(m.ProviderOperations.WithLabelValues("delete", service.ErrCodeNotFound))).To(Equal(1.0))
+		})
+	})
+
+	Describe("debug logging", func() {
+		It("stores the flag and clears it when it is omitted on update", func() {
+			enabled := true
+			req := newProvider("debugged")
+			req.DebugLogging = &enabled
+
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*resp.DebugLogging).To(BeTrue())
+
+			updated, err := providerService.UpdateProvider(ctx, resp.Id.String(), newProvider("debugged"), false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated.DebugLogging).To(BeFalse())
+		})
+	})
+
+	Describe("GetProviderByName", func() {
+		It("returns the provider", func() {
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("by-name"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			provider, err := providerService.GetProviderByName(ctx, "by-name")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.Id).To(Equal(resp.Id))
+		})
+
+		It("returns not found for an unknown name", func() {
+			_, err := providerService.GetProviderByName(ctx, "unknown")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeNotFound))
+		})
+
+		It("does not return a deleted provider", func() {
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("deleted-by-name"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providerService.DeleteProvider(ctx, resp.Id.String(), false)).To(Succeed())
+
+			_, err = providerService.GetProviderByName(ctx, "deleted-by-name")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeNotFound))
+		})
+	})
+
+	Describe("GetProvider", func() {
+		It("returns the provider", func() {
+			req := newProvider("get-test")
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			provider, err := providerService.GetProvider(ctx, resp.Id.String())
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.Name).To(Equal("get-test"))
+		})
+
+		It("includes the health check state", func() {
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, newProvider("get-health"), nil, false)
+			nextCheck := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+			Expect(dataStore.Provider().UpdateHealthStatus(ctx, uuid.UUID(*resp.Id), model.HealthStatusNotReady, 3, nextCheck)).To(Succeed())
+
+			provider, err := providerService.GetProvider(ctx, resp.Id.String())
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*provider.HealthStatus).To(Equal("not_ready"))
+			Expect(*provider.ConsecutiveFailures).To(Equal(3))
+			Expect(provider.NextHealthCheckTime.Equal(nextCheck)).To(BeTrue())
+		})
+
+		It("returns error for non-existent provider", func() {
+			_, err := providerService.GetProvider(ctx, uuid.New().String())
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+		})
+	})
+
+	Describe("GetProvider with a response cache", func() {
+		var (
+			counting      *countingStore
+			cachedService *service.ProviderService
+			registeredID  string
+		)
+
+		BeforeEach(func() {
+			counting = &countingStore{Store: dataStore, provider: &countingProviderStore{Provider: dataStore.Provider()}}
+			cachedService = service.NewProviderService(counting, service.WithResponseCache(10, time.Minute))
+			resp, err := cachedService.RegisterOrUpdateProvider(ctx, newProvider("cached-provider"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			registeredID = resp.Id.String()
+		})
+
+		It("serves repeated reads from the cache", func() {
+			for range 3 {
+				provider, err := cachedService.GetProvider(ctx, registeredID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(provider.Name).To(Equal("cached-provider"))
+			}
+
+			Expect(counting.provider.gets.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("evicts the provider when it is updated", func() {
+			_, err := cachedService.GetProvider(ctx, registeredID)
+			Expect(err).NotTo(HaveOccurred())
+
+			update := newProvider("cached-provider")
+			update.Endpoint = "https://updated.example.com"
+			_, err = cachedService.UpdateProvider(ctx, registeredID, update, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			provider, err := cachedService.GetProvider(ctx, registeredID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider.Endpoint).To(Equal("https://updated.example.com"))
+		})
+
+		It("evicts the provider when it is deleted", func() {
+			_, err := cachedService.GetProvider(ctx, registeredID)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cachedService.DeleteProvider(ctx, registeredID, false)).To(Succeed())
+
+			_, err = cachedService.GetProvider(ctx, registeredID)
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+		})
+
+		It("evicts the provider when its health status is stored outside the service", func() {
+			_, err := cachedService.GetProvider(ctx, registeredID)
+			Expect(err).NotTo(HaveOccurred())
+
+			id := uuid.MustParse(registeredID)
+			Expect(dataStore.Provider().UpdateHealthStatus(ctx, id, model.HealthStatusNotReady, 3, time.Now())).To(Succeed())
+			cachedService.InvalidateCachedProvider(id)
+
+			provider, err := cachedService.GetProvider(ctx, registeredID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*provider.HealthStatus).To(Equal(string(model.HealthStatusNotReady)))
+		})
+
+		It("reads through to the store when disabled", func() {
+			uncached := service.NewProviderService(counting, service.WithResponseCache(0, time.Minute))
+			for range 3 {
+				_, err := uncached.GetProvider(ctx, registeredID)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(counting.provider.gets.Load()).To(BeEquivalentTo(3))
+		})
+	})
+
+	Describe("ListProviders", func() {
+		It("returns all providers", func() {
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("p1"), nil, false)
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("p2"), nil, false)
+
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(2))
+		})
+
+		It("filters by service type", func() {
+			req1 := newProvider("vm-provider")
+			req1.ServiceType = "vm"
+			providerService.RegisterOrUpdateProvider(ctx, req1, nil, false)
+
+			req2 := newProvider("container-provider")
+			req2.ServiceType = "container"
+			providerService.RegisterOrUpdateProvider(ctx, req2, nil, false)
+
+			result, err := providerService.ListProviders(ctx, "vm", "", "", "", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(1))
+		})
+
+		It("filters by health status together with service type", func() {
+			for _, name := range []string{"vm-up", "vm-down", "container-down"} {
+				req := newProvider(name)
+				req.ServiceType = strings.SplitN(name, "-", 2)[0]
+				resp, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				if strings.HasSuffix(name, "-down") {
+					Expect(dataStore.Provider().UpdateHealthStatus(ctx, uuid.UUID(*resp.Id), model.HealthStatusNotReady, 3, time.Now())).To(Succeed())
+				}
+			}
+
+			result, err := providerService.ListProviders(ctx, "vm", "not_ready", "", "", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(1))
+			Expect(result.Providers[0].Name).To(Equal("vm-down"))
+		})
+
+		It("filters by a name substring", func() {
+			for _, name := range []string{"search-east", "search-west", "other"} {
+				_, err := providerService.RegisterOrUpdateProvider(ctx, newProvider(name), nil, false)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			result, err := providerService.ListProviders(ctx, "", "", "search", "", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(2))
+			Expect(*result.TotalSize).To(Equal(int64(2)))
+		})
+
+		It("filters by labels", func() {
+			for name, labels := range map[string]map[string]string{
+				"labels-east-infra": {"region": "us-east", "team": "infra"},
+				"labels-east-apps":  {"region": "us-east", "team": "apps"},
+				"labels-none":       nil,
+			} {
+				req := newProvider(name)
+				if labels != nil {
+					req.Labels = &labels
+				}
+				_, err := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			result, err := providerService.ListProviders(ctx, "", "", "", "region=us-east, team=infra", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(1))
+			Expect(result.Providers[0].Name).To(Equal("labels-east-infra"))
+			Expect(*result.Providers[0].Labels).To(Equal(map[string]string{"region": "us-east", "team": "infra"}))
+		})
+
+		It("returns error for a malformed label selector", func() {
+			_, err := providerService.ListProviders(ctx, "", "", "", "region", 0, "", "", false, false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("returns error for an unknown health status", func() {
+			_, err := providerService.ListProviders(ctx, "", "broken", "", "", 0, "", "", false, false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("returns error for negative page size", func() {
+			_, err := providerService.ListProviders(ctx, "", "", "", "", -1, "", "", false, false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("coerces page size to max", func() {
+			for i := 0; i < 5; i++ {
+				providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("coerce-p%d", i)), nil, false)
+			}
+
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 2, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(2))
+			Expect(result.NextPageToken).NotTo(BeEmpty())
+		})
+
+		Context("with configured page sizes", func() {
+			var sizedService *service.ProviderService
+
+			BeforeEach(func() {
+				sizedService = service.NewProviderService(dataStore, service.WithPageSizes(2, 3))
+				for i := 0; i < 5; i++ {
+					_, err := sizedService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("sized-p%d", i)), nil, false)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("uses the default page size when none is requested", func() {
+				result, err := sizedService.ListProviders(ctx, "", "", "", "", 0, "", "", false, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Providers).To(HaveLen(2))
+			})
+
+			It("clamps a page size above the max", func() {
+				result, err := sizedService.ListProviders(ctx, "", "", "", "", 50, "", "", false, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Providers).To(HaveLen(3))
+				Expect(result.NextPageToken).NotTo(BeEmpty())
+			})
+		})
+
+		It("paginates through results", func() {
+			for i := 0; i < 5; i++ {
+				providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("paginate-p%d", i)), nil, false)
+			}
+
+			// First page
+			result1, err := providerService.ListProviders(ctx, "", "", "", "", 2, "", "", false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result1.Providers).To(HaveLen(2))
+			Expect(result1.NextPageToken).NotTo(BeEmpty())
+
+			// Second page
+			result2, err := providerService.ListProviders(ctx, "", "", "", "", 2, result1.NextPageToken, "", false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result2.Providers).To(HaveLen(2))
+			Expect(result2.NextPageToken).NotTo(BeEmpty())
+
+			// Third page (last)
+			result3, err := providerService.ListProviders(ctx, "", "", "", "", 2, result2.NextPageToken, "", false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result3.Providers).To(HaveLen(1))
+			Expect(result3.NextPageToken).To(BeEmpty())
+		})
+
+		It("neither skips nor repeats providers when earlier ones change between pages", func() {
+			var ids []string
+			for i := 0; i < 4; i++ {
+				resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("keyset-p%d", i)), nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				ids = append(ids, resp.Id.String())
+			}
+
+			result1, err := providerService.ListProviders(ctx, "", "", "", "", 2, "", "", false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providerService.DeleteProvider(ctx, ids[0], false)).To(Succeed())
+
+			result2, err := providerService.ListProviders(ctx, "", "", "", "", 2, result1.NextPageToken, "", false, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			seen := map[string]bool{}
+			for _, p := range append(result1.Providers, result2.Providers...) {
+				Expect(seen).NotTo(HaveKey(p.Name))
+				seen[p.Name] = true
+			}
+			Expect(seen).To(HaveLen(4))
+			Expect(result2.NextPageToken).To(BeEmpty())
+		})
+
+		It("still accepts a legacy offset page token", func() {
+			for i := 0; i < 3; i++ {
+				providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("legacy-p%d", i)), nil, false)
+			}
+
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 2, pagination.EncodeOffset(2), "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(1))
+			Expect(result.NextPageToken).To(BeEmpty())
+		})
+
+		It("returns error for invalid page token", func() {
+			_, err := providerService.ListProviders(ctx, "", "", "", "", 0, "invalid-token", "", false, false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("reports the same total size on every page", func() {
+			for i := 0; i < 5; i++ {
+				providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("total-p%d", i)), nil, false)
+			}
+
+			token := ""
+			for range 3 {
+				result, err := providerService.ListProviders(ctx, "", "", "", "", 2, token, "", false, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.TotalSize).NotTo(BeNil())
+				Expect(*result.TotalSize).To(Equal(int64(5)))
+				token = result.NextPageToken
+			}
+			Expect(token).To(BeEmpty())
+		})
+
+		It("counts only the providers matching the filter", func() {
+			container := newProvider("total-container")
+			container.ServiceType = "container"
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("total-vm"), nil, false)
+			providerService.RegisterOrUpdateProvider(ctx, container, nil, false)
+
+			result, err := providerService.ListProviders(ctx, "vm", "", "", "", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*result.TotalSize).To(Equal(int64(1)))
+		})
+
+		It("does not count the providers when the total size is skipped", func() {
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("total-skipped"), nil, false)
+
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "", false, true)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(1))
+			Expect(result.TotalSize).To(BeNil())
+		})
+
+		Context("with order_by", func() {
+			names := func(providers []server.Provider) []string {
+				var result []string
+				for _, p := range providers {
+					result = append(result, p.Name)
+				}
+				return result
+			}
+
+			BeforeEach(func() {
+				for _, name := range []string{"bravo", "delta", "alpha", "charlie", "echo"} {
+					_, err := providerService.RegisterOrUpdateProvider(ctx, newProvider(name), nil, false)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("pages through the providers by name descending", func() {
+				result1, err := providerService.ListProviders(ctx, "", "", "", "", 2, "", "name desc", false, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names(result1.Providers)).To(Equal([]string{"echo", "delta"}))
+
+				result2, err := providerService.ListProviders(ctx, "", "", "", "", 2, result1.NextPageToken, "name desc", false, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names(result2.Providers)).To(Equal([]string{"charlie", "bravo"}))
+
+				result3, err := providerService.ListProviders(ctx, "", "", "", "", 2, result2.NextPageToken, "name desc", false, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names(result3.Providers)).To(Equal([]string{"alpha"}))
+				Expect(result3.NextPageToken).To(BeEmpty())
+			})
+
+			It("orders by name ascending without a direction", func() {
+				result, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "name", false, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names(result.Providers)).To(Equal([]string{"alpha", "bravo", "charlie", "delta", "echo"}))
+			})
+
+			It("rejects a field outside the allowlist", func() {
+				_, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "endpoint; DROP TABLE providers", false, false)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+			})
+
+			It("rejects an unknown direction", func() {
+				_, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "name sideways", false, false)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+			})
+
+			It("rejects a page token issued for a different order", func() {
+				result, err := providerService.ListProviders(ctx, "", "", "", "", 2, "", "name desc", false, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = providerService.ListProviders(ctx, "", "", "", "", 2, result.NextPageToken, "", false, false)
+
+				Expect(err).To(HaveOccurred())
+				svcErr := err.(*service.ServiceError)
+				Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+				Expect(svcErr.Message).To(ContainSubstring("different order_by"))
+			})
+		})
+
+		Context("with a page token past the end", func() {
+			staleToken := pagination.EncodeOffset(50)
+
+			BeforeEach(func() {
+				for i := range 3 {
+					providerService.RegisterOrUpdateProvider(ctx, newProvider(fmt.Sprintf("stale-p%d", i)), nil, false)
+				}
+			})
+
+			It("returns an empty last page by default", func() {
+				result, err := providerService.ListProviders(ctx, "", "", "", "", 2, staleToken, "", false, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Providers).To(BeEmpty())
+				Expect(result.NextPageToken).To(BeEmpty())
+			})
+
+			It("rejects the token when strict page tokens are enabled", func() {
+				strict := service.NewProviderService(dataStore, service.WithStrictPageTokens(true))
+
+				_, err := strict.ListProviders(ctx, "", "", "", "", 2, staleToken, "", false, false)
+
+				Expect(err).To(HaveOccurred())
+				svcErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+			})
+
+			It("rejects a negative offset", func() {
+				_, err := providerService.ListProviders(ctx, "", "", "", "", 2, base64.StdEncoding.EncodeToString([]byte("-1")), "", false, false)
+
+				Expect(err).To(HaveOccurred())
+				svcErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+			})
+
+			It("rejects a tampered cursor", func() {
+				tampered := base64.StdEncoding.EncodeToString([]byte(`{"create_time":"2025-01-01T00:00:00Z","id":"` + uuid.NewString() + `","offset":0}`))
+
+				_, err := providerService.ListProviders(ctx, "", "", "", "", 2, tampered, "", false, false)
+
+				Expect(err).To(HaveOccurred())
+				svcErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(svcErr.Code).To(Equal(service.ErrCodeValidation))
+			})
+		})
+	})
+
+	Describe("ListProviders with instance counts", func() {
+		var counting *countingStore
+
+		BeforeEach(func() {
+			counting = &countingStore{Store: dataStore, provider: &countingProviderStore{Provider: dataStore.Provider()}}
+			providerService = service.NewProviderService(counting)
+
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("busy"), nil, false)
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("idle"), nil, false)
+			for i := range 3 {
+				_, err := dataStore.ServiceTypeInstance().Create(ctx, model.ServiceTypeInstance{
+					ID:           uuid.New(),
+					ProviderName: "busy",
+					Status:       "RUNNING",
+					InstanceName: fmt.Sprintf("instance-%d", i),
+					Spec:         []byte("{}"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("includes each provider's instance count when requested", func() {
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "", true, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(2))
+			counts := map[string]int64{}
+			for _, p := range result.Providers {
+				Expect(p.InstanceCount).NotTo(BeNil())
+				counts[p.Name] = *p.InstanceCount
+			}
+			Expect(counts).To(Equal(map[string]int64{"busy": 3, "idle": 0}))
+			Expect(counting.provider.countedLists.Load()).To(BeEquivalentTo(1))
+			Expect(counting.provider.lists.Load()).To(BeZero())
+		})
+
+		It("respects pagination", func() {
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 1, "", "", true, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers).To(HaveLen(1))
+			Expect(*result.Providers[0].InstanceCount).To(BeEquivalentTo(3))
+			Expect(result.NextPageToken).NotTo(BeEmpty())
+		})
+
+		It("does not count instances by default", func() {
+			result, err := providerService.ListProviders(ctx, "", "", "", "", 0, "", "", false, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Providers[0].InstanceCount).To(BeNil())
+			Expect(counting.provider.lists.Load()).To(BeEquivalentTo(1))
+			Expect(counting.provider.countedLists.Load()).To(BeZero())
+		})
+	})
+
+	Describe("PatchProvider", func() {
+		var existing *server.Provider
+
+		BeforeEach(func() {
+			providerService = service.NewProviderService(dataStore, service.WithSchemaVersions("v1alpha1", "v1beta1"))
+			var err error
+			req := newProvider("to-patch")
+			seconds := 30
+			req.TimeoutSeconds = &seconds
+			existing, err = providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		patch := func(p server.ProviderPatch) *server.Provider {
+			resp, err := providerService.PatchProvider(ctx, existing.Id.String(), &p)
+			Expect(err).NotTo(HaveOccurred())
+			got, err := providerService.GetProvider(ctx, existing.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(resp))
+			return resp
+		}
+
+		// unchanged checks that the fields the patch did not set kept their value.
+		unchanged := func(resp *server.Provider, except ...string) {
+			fields := map[string][2]any{
+				"name":            {resp.Name, existing.Name},
+				"endpoint":        {resp.Endpoint, existing.Endpoint},
+				"service_type":    {resp.ServiceType, existing.ServiceType},
+				"schema_version":  {resp.SchemaVersion, existing.SchemaVersion},
+				"debug_logging":   {*resp.DebugLogging, *existing.DebugLogging},
+				"timeout_seconds": {*resp.TimeoutSeconds, *existing.TimeoutSeconds},
+				"health_path":     {*resp.HealthPath, *existing.HealthPath},
+				"health_disabled": {*resp.HealthCheckDisabled, *existing.HealthCheckDisabled},
+				"health_statuses": {resp.HealthExpectedStatuses, existing.HealthExpectedStatuses},
+				"maintenance":     {resp.MaintenanceWindow, existing.MaintenanceWindow},
+				"labels":          {resp.Labels, existing.Labels},
+				"create_path":     {resp.CreatePath, existing.CreatePath},
+				"get_path":        {resp.GetPath, existing.GetPath},
+				"delete_path":     {resp.DeletePath, existing.DeletePath},
+				"auth":            {resp.Auth, existing.Auth},
+			}
+			for _, field := range except {
+				delete(fields, field)
+			}
+			for field, values := range fields {
+				Expect(values[0]).To(Equal(values[1]), field)
+			}
+		}
+
+		It("rejects one of two concurrent patches based on the same version", func() {
+			sqlDB, _ := db.DB()
+			sqlDB.SetMaxOpenConns(1)
+
+			racing := &patchRacingStore{Store: dataStore, provider: &patchRacingProviderStore{Provider: dataStore.Provider()}}
+			racing.provider.reads.Add(2)
+			racingService := service.NewProviderService(racing)
+
+			var wg sync.WaitGroup
+			errs := make([]error, 2)
+			for i := range 2 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					endpoint := fmt.Sprintf("https://patch-%d.example.com", i)
+					_, errs[i] = racingService.PatchProvider(ctx, existing.Id.String(), &server.ProviderPatch{Version: existing.Version, Endpoint: &endpoint})
+				}()
+			}
+			wg.Wait()
+
+			var conflicts int
+			for _, err := range errs {
+				if err != nil {
+					Expect(err).To(BeAssignableToTypeOf(&service.ServiceError{}))
+					Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeConflict))
+					conflicts++
+				}
+			}
+			Expect(conflicts).To(Equal(1))
+
+			got, err := providerService.GetProvider(ctx, existing.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*got.Version).To(Equal(*existing.Version + 1))
+		})
+
+		It("rejects a patch based on an older version", func() {
+			endpoint := "https://first.example.com"
+			patch(server.ProviderPatch{Version: existing.Version, Endpoint: &endpoint})
+
+			name := "renamed"
+			_, err := providerService.PatchProvider(ctx, existing.Id.String(), &server.ProviderPatch{Version: existing.Version, Name: &name})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeConflict))
+			got, err := providerService.GetProvider(ctx, existing.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Name).To(Equal("to-patch"))
+		})
+
+		It("patches the name", func() {
+			name := "patched"
+			resp := patch(server.ProviderPatch{Name: &name})
+			Expect(resp.Name).To(Equal("patched"))
+			unchanged(resp, "name")
+		})
+
+		It("patches the endpoint", func() {
+			endpoint := "https://patched.example.com"
+			resp := patch(server.ProviderPatch{Endpoint: &endpoint})
+			Expect(resp.Endpoint).To(Equal(endpoint))
+			unchanged(resp, "endpoint")
+		})
+
+		It("patches the service type", func() {
+			serviceType := "container"
+			resp := patch(server.ProviderPatch{ServiceType: &serviceType})
+			Expect(resp.ServiceType).To(Equal("container"))
+			unchanged(resp, "service_type")
+		})
+
+		It("patches the schema version", func() {
+			version := "v1beta1"
+			resp := patch(server.ProviderPatch{SchemaVersion: &version})
+			Expect(resp.SchemaVersion).To(Equal("v1beta1"))
+			unchanged(resp, "schema_version")
+		})
+
+		It("rejects an unsupported schema version", func() {
+			version := "v2"
+			_, err := providerService.PatchProvider(ctx, existing.Id.String(), &server.ProviderPatch{SchemaVersion: &version})
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("patches debug logging", func() {
+			enabled := true
+			resp := patch(server.ProviderPatch{DebugLogging: &enabled})
+			Expect(*resp.DebugLogging).To(BeTrue())
+			unchanged(resp, "debug_logging")
+		})
+
+		It("patches the timeout to zero", func() {
+			seconds := 0
+			resp := patch(server.ProviderPatch{TimeoutSeconds: &seconds})
+			Expect(*resp.TimeoutSeconds).To(BeZero())
+			unchanged(resp, "timeout_seconds")
+		})
+
+		It("patches the health path", func() {
+			path := "/readyz"
+			resp := patch(server.ProviderPatch{HealthPath: &path})
+			Expect(*resp.HealthPath).To(Equal("/readyz"))
+			unchanged(resp, "health_path")
+		})
+
+		It("patches the health check opt-out", func() {
+			disabled := true
+			resp := patch(server.ProviderPatch{HealthCheckDisabled: &disabled})
+			Expect(*resp.HealthCheckDisabled).To(BeTrue())
+			unchanged(resp, "health_disabled")
+		})
+
+		It("patches the expected statuses and clears them with an empty list", func() {
+			resp := patch(server.ProviderPatch{HealthExpectedStatuses: &[]int{200, 204}})
+			Expect(*resp.HealthExpectedStatuses).To(Equal([]int{200, 204}))
+			unchanged(resp, "health_statuses")
+
+			resp = patch(server.ProviderPatch{HealthExpectedStatuses: &[]int{}})
+			Expect(resp.HealthExpectedStatuses).To(BeNil())
+		})
+
+		It("patches the maintenance window", func() {
+			start := time.Now().UTC().Truncate(time.Second)
+			window := server.MaintenanceWindow{StartTime: start, EndTime: start.Add(time.Hour)}
+			resp := patch(server.ProviderPatch{MaintenanceWindow: &window})
+			Expect(resp.MaintenanceWindow.StartTime).To(BeTemporally("==", window.StartTime))
+			Expect(resp.MaintenanceWindow.EndTime).To(BeTemporally("==", window.EndTime))
+			unchanged(resp, "maintenance")
+		})
+
+		It("replaces the labels and removes them with an empty object", func() {
+			resp := patch(server.ProviderPatch{Labels: &map[string]string{"region": "us-east"}})
+			Expect(*resp.Labels).To(Equal(map[string]string{"region": "us-east"}))
+			unchanged(resp, "labels")
+
+			resp = patch(server.ProviderPatch{Labels: &map[string]string{}})
+			Expect(resp.Labels).To(BeNil())
+		})
+
+		It("patches the instance paths", func() {
+			createPath, getPath, deletePath := "/v1/vms", "/v1/vms/{id}", "/v1/vms/{id}/delete"
+			resp := patch(server.ProviderPatch{CreatePath: &createPath, GetPath: &getPath, DeletePath: &deletePath})
+			Expect(*resp.CreatePath).To(Equal(createPath))
+			Expect(*resp.GetPath).To(Equal(getPath))
+			Expect(*resp.DeletePath).To(Equal(deletePath))
+			unchanged(resp, "create_path", "get_path", "delete_path")
+		})
+
+		It("patches the credentials, keeping the stored token when it is omitted", func() {
+			token := "t0ken"
+			resp := patch(server.ProviderPatch{Auth: &server.ProviderAuth{Type: server.Bearer, Token: &token}})
+			Expect(resp.Auth.Type).To(Equal(server.Bearer))
+			Expect(resp.Auth.Token).To(BeNil())
+			unchanged(resp, "auth")
+
+			header := "X-Api-Key"
+			resp = patch(server.ProviderPatch{Auth: &server.ProviderAuth{Type: server.Bearer}})
+			Expect(resp.Auth.Type).To(Equal(server.Bearer))
+			stored, err := dataStore.Provider().Get(ctx, uuid.UUID(*existing.Id))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.AuthToken).To(Equal("t0ken"))
+
+			_, err = providerService.PatchProvider(ctx, existing.Id.String(), &server.ProviderPatch{Auth: &server.ProviderAuth{Type: server.Header, HeaderName: &header}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		DescribeTable("rejects a value a full update rejects",
+			func(p server.ProviderPatch) {
+				_, err := providerService.PatchProvider(ctx, existing.Id.String(), &p)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+				got, err := providerService.GetProvider(ctx, existing.Id.String())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*got.Version).To(Equal(*existing.Version))
+			},
+			Entry("a health path with a query", server.ProviderPatch{HealthPath: ptr("/health?verbose=1")}),
+			Entry("a health path with a host", server.ProviderPatch{HealthPath: ptr("//other.example.com/health")}),
+			Entry("an out of range expected status", server.ProviderPatch{HealthExpectedStatuses: &[]int{99}}),
+			Entry("a negative timeout", server.ProviderPatch{TimeoutSeconds: ptr(-1)}),
+			Entry("a maintenance window ending before it starts", server.ProviderPatch{MaintenanceWindow: &server.MaintenanceWindow{
+				StartTime: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			}}),
+			Entry("an invalid label", server.ProviderPatch{Labels: &map[string]string{"region": "us east"}}),
+			Entry("a create path with the instance ID", server.ProviderPatch{CreatePath: ptr("/v1/vms/{id}")}),
+			Entry("a get path without the instance ID", server.ProviderPatch{GetPath: ptr("/v1/vms")}),
+			Entry("a delete path without the instance ID", server.ProviderPatch{DeletePath: ptr("/v1/vms")}),
+			Entry("credentials without a token", server.ProviderPatch{Auth: &server.ProviderAuth{Type: server.Bearer}}),
+		)
+
+		It("returns the provider unchanged for an empty patch", func() {
+			before, err := providerService.GetProvider(ctx, existing.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(patch(server.ProviderPatch{})).To(Equal(before))
+		})
+
+		It("returns conflict error when the name is taken", func() {
+			_, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("other"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			name := "other"
+
+			_, err = providerService.PatchProvider(ctx, existing.Id.String(), &server.ProviderPatch{Name: &name})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeConflict))
+		})
+
+		It("rejects an empty required field", func() {
+			endpoint := ""
+
+			_, err := providerService.PatchProvider(ctx, existing.Id.String(), &server.ProviderPatch{Endpoint: &endpoint})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeValidation))
+		})
+
+		It("returns not found error for non-existent provider", func() {
+			_, err := providerService.PatchProvider(ctx, uuid.New().String(), &server.ProviderPatch{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeNotFound))
+		})
+	})
+
+	Describe("UpdateProvider", func() {
+		It("updates the provider", func() {
+			req := newProvider("update-provider")
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			update := &server.Provider{
+				Id:            resp.Id,
+				Name:          "update-provider",
+				Endpoint:      "https://updated.example.com",
+				ServiceType:   "vm",
+				SchemaVersion: "v1alpha1",
+			}
+
+			updated, err := providerService.UpdateProvider(ctx, resp.Id.String(), update, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Endpoint).To(Equal("https://updated.example.com"))
+		})
+
+		It("rejects the second of two updates based on the same version", func() {
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("versioned"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*resp.Version).To(Equal(1))
+
+			first := newProvider("versioned")
+			first.Endpoint = "https://first.example.com"
+			first.Version = resp.Version
+			updated, err := providerService.UpdateProvider(ctx, resp.Id.String(), first, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*updated.Version).To(Equal(2))
+
+			second := newProvider("versioned")
+			second.Endpoint = "https://second.example.com"
+			second.Version = resp.Version
+			_, err = providerService.UpdateProvider(ctx, resp.Id.String(), second, false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr := err.(*service.ServiceError)
+			Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
+			Expect(svcErr.Message).To(Equal("provider was modified concurrently"))
+			got, err := providerService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got.Endpoint).To(Equal("https://first.example.com"))
+		})
+
+		It("returns conflict when renaming to existing name", func() {
+			// Create two providers
+			providerService.RegisterOrUpdateProvider(ctx, newProvider("original-name"), nil, false)
+			resp2, _ := providerService.RegisterOrUpdateProvider(ctx, newProvider("to-rename"), nil, false)
+
+			// Try to rename second provider to first provider's name
+			update := &server.Provider{
+				Name:          "original-name",
+				Endpoint:      "https://example.com",
+				ServiceType:   "vm",
+				SchemaVersion: "v1alpha1",
+			}
+
+			_, err := providerService.UpdateProvider(ctx, resp2.Id.String(), update, false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
+		})
+
+		It("returns conflict when the name is taken after it was checked", func() {
+			_, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("taken-later"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("to-rename"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			staleLookups := service.NewProviderService(&staleNameStore{Store: dataStore})
+
+			_, err = staleLookups.UpdateProvider(ctx, resp.Id.String(), newProvider("taken-later"), false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
+		})
+
+		Context("with endpoint validation", func() {
+			var (
+				registeredID string
+				healthy      *httptest.Server
+			)
+
+			BeforeEach(func() {
+				healthy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+				resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("migrating-provider"), nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				registeredID = resp.Id.String()
+			})
+
+			AfterEach(func() {
+				healthy.Close()
+			})
+
+			It("switches to a healthy endpoint", func() {
+				update := newProvider("migrating-provider")
+				update.Endpoint = healthy.URL
+
+				updated, err := providerService.UpdateProvider(ctx, registeredID, update, true)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.Endpoint).To(Equal(healthy.URL))
+			})
+
+			It("rejects an unreachable endpoint and keeps the old one", func() {
+				unreachable := httptest.NewServer(http.NotFoundHandler())
+				unreachable.Close()
+				update := newProvider("migrating-provider")
+				update.Endpoint = unreachable.URL
+
+				_, err := providerService.UpdateProvider(ctx, registeredID, update, true)
+
+				Expect(err).To(HaveOccurred())
+				svcErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(svcErr.Code).To(Equal(service.ErrCodeProviderError))
+
+				provider, err := providerService.GetProvider(ctx, registeredID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(provider.Endpoint).To(Equal("https://example.com/api"))
+			})
+
+			It("does not probe an unchanged endpoint", func() {
+				update := newProvider("migrating-provider")
+				update.ServiceType = "container"
+
+				updated, err := providerService.UpdateProvider(ctx, registeredID, update, true)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updated.ServiceType).To(Equal("container"))
+			})
+		})
+
+		It("returns error for non-existent provider", func() {
+			update := &server.Provider{
+				Name:          "test",
+				Endpoint:      "https://example.com",
+				ServiceType:   "vm",
+				SchemaVersion: "v1alpha1",
+			}
+
+			_, err := providerService.UpdateProvider(ctx, uuid.New().String(), update, false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+		})
+	})
+
+	Describe("DeleteProvider", func() {
+		It("deletes the provider", func() {
+			req := newProvider("to-delete")
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, req, nil, false)
+
+			err := providerService.DeleteProvider(ctx, resp.Id.String(), false)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns error for non-existent provider", func() {
+			err := providerService.DeleteProvider(ctx, uuid.New().String(), false)
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+		})
+
+		Context("when the provider has instances", func() {
+			var providerID string
+
+			BeforeEach(func() {
+				resp, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("busy"), nil, false)
+				Expect(err).NotTo(HaveOccurred())
+				providerID = resp.Id.String()
+				_, err = dataStore.ServiceTypeInstance().Create(ctx, model.ServiceTypeInstance{
+					ID:           uuid.New(),
+					ProviderName: "busy",
+					Status:       "RUNNING",
+					InstanceName: "instance",
+					Spec:         []byte("{}"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns conflict error", func() {
+				err := providerService.DeleteProvider(ctx, providerID, false)
+
+				Expect(err).To(HaveOccurred())
+				svcErr, ok := err.(*service.ServiceError)
+				Expect(ok).To(BeTrue())
+				Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
+				Expect(svcErr.Message).To(Equal("provider has 1 active instances"))
+				_, err = providerService.GetProvider(ctx, providerID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the provider with force", func() {
+				Expect(providerService.DeleteProvider(ctx, providerID, true)).To(Succeed())
+
+				_, err := providerService.GetProvider(ctx, providerID)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("previews a forced delete without deleting anything", func() {
+				preview, err := providerService.PreviewDeleteProvider(ctx, providerID, true)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(preview.Provider.Name).To(Equal("busy"))
+				Expect(preview.InstanceCount).To(Equal(int64(1)))
+				_, err = providerService.GetProvider(ctx, providerID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dataStore.ServiceTypeInstance().CountByProvider(ctx, "busy")).To(Equal(int64(1)))
+			})
+
+			It("reports the conflict the delete would hit in a preview", func() {
+				_, err := providerService.PreviewDeleteProvider(ctx, providerID, false)
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeConflict))
+			})
+		})
+
+		It("returns not found when previewing the delete of a missing provider", func() {
+			_, err := providerService.PreviewDeleteProvider(ctx, uuid.New().String(), false)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*service.ServiceError).Code).To(Equal(service.ErrCodeNotFound))
+		})
+	})
+
+	Describe("RestoreProvider", func() {
+		It("restores a deleted provider", func() {
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, newProvider("to-restore"), nil, false)
+			Expect(providerService.DeleteProvider(ctx, resp.Id.String(), false)).To(Succeed())
+
+			restored, err := providerService.RestoreProvider(ctx, resp.Id.String())
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.Name).To(Equal("to-restore"))
+			_, err = providerService.GetProvider(ctx, resp.Id.String())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns not found error for a provider that is not deleted", func() {
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, newProvider("live"), nil, false)
+
+			_, err := providerService.RestoreProvider(ctx, resp.Id.String())
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeNotFound))
+		})
+
+		It("returns conflict error when the name was registered again", func() {
+			resp, _ := providerService.RegisterOrUpdateProvider(ctx, newProvider("reused"), nil, false)
+			Expect(providerService.DeleteProvider(ctx, resp.Id.String(), false)).To(Succeed())
+			_, err := providerService.RegisterOrUpdateProvider(ctx, newProvider("reused"), nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = providerService.RestoreProvider(ctx, resp.Id.String())
+
+			Expect(err).To(HaveOccurred())
+			svcErr, ok := err.(*service.ServiceError)
+			Expect(ok).To(BeTrue())
+			Expect(svcErr.Code).To(Equal(service.ErrCodeConflict))
+		})
+	})
+})
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newProvider(name string) *server.Provider {
+	return &server.Provider{
+		Name:          name,
+		Endpoint:      "https://example.com/api",
+		ServiceType:   "vm",
+		SchemaVersion: "v1alpha1",
+	}
+}
+
+// racingStore wraps a store so that concurrent registrations race on the insert.
+type racingStore struct {
+	store.Store
+	provider *racingProviderStore
+}
+
+func (s *racingStore) Provider() store.Provider {
+	return s.provider
+}
+
+// racingProviderStore holds the first name lookups until all of them have completed,
+// so every participant sees the name as free before any of them inserts it.
+type racingProviderStore struct {
+	store.Provider
+	lookups sync.WaitGroup
+	calls   atomic.Int32
+}
+
+func (p *racingProviderStore) GetByName(ctx context.Context, name string) (*model.Provider, error) {
+	found, err := p.Provider.GetByName(ctx, name)
+	if p.calls.Add(1) <= 2 {
+		p.lookups.Done()
+		p.lookups.Wait()
+	}
+	return found, err
+}
+
+// staleNameStore wraps a store whose name lookups find nothing, as when another
+// provider takes the name between the lookup and the write.
+type staleNameStore struct {
+	store.Store
+}
+
+func (s *staleNameStore) Provider() store.Provider {
+	return staleNameProviderStore{s.Store.Provider()}
+}
+
+type staleNameProviderStore struct {
+	store.Provider
+}
+
+func (staleNameProviderStore) GetByName(context.Context, string) (*model.Provider, error) {
+	return nil, store.ErrProviderNotFound
+}
+
+// patchRacingStore wraps a store so that concurrent patches race on the write.
+type patchRacingStore struct {
+	store.Store
+	provider *patchRacingProviderStore
+}
+
+func (s *patchRacingStore) Provider() store.Provider {
+	return s.provider
+}
+
+// patchRacingProviderStore holds the first reads until all of them have completed,
+// so every patch sees the same version before any of them writes.
+type patchRacingProviderStore struct {
+	store.Provider
+	reads sync.WaitGroup
+	calls atomic.Int32
+}
+
+func (p *patchRacingProviderStore) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	found, err := p.Provider.Get(ctx, id)
+	if p.calls.Add(1) <= 2 {
+		p.reads.Done()
+		p.reads.Wait()
+	}
+	return found, err
+}
+
+// countingStore wraps a store to count provider reads.
+type countingStore struct {
+	store.Store
+	provider *countingProviderStore
+}
+
+func (s *countingStore) Provider() store.Provider {
+	return s.provider
+}
+
+type countingProviderStore struct {
+	store.Provider
+	gets         atomic.Int32
+	lists        atomic.Int32
+	countedLists atomic.Int32
+}
+
+func (p *countingProviderStore) List(ctx context.Context, filter *store.ProviderFilter, pagination *store.Pagination) (model.ProviderList, error) {
+	p.lists.Add(1)
+	return p.Provider.List(ctx, filter, pagination)
+}
+
+func (p *countingProviderStore) ListWithInstanceCounts(ctx context.Context, filter *store.ProviderFilter, pagination *store.Pagination) ([]store.ProviderWithInstanceCount, error) {
+	p.countedLists.Add(1)
+	return p.Provider.ListWithInstanceCounts(ctx, filter, pagination)
+}
+
+func (p *countingProviderStore) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	p.gets.Add(1)
+	return p.Provider.Get(ctx, id)
 }
@@ -1,12 +1,15 @@
 package store
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/config"
-	"github.com/dcm-project/service-provider-manager/internal/store/model"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -14,9 +17,15 @@ import (
 )
 
 func InitDB(cfg *config.Config) (*gorm.DB, error) {
-	var dialector gorm.Dialector
+	var (
+		dialector gorm.Dialector
+		path      string
+	)
 
 	if cfg.Database.Type == "pgsql" {
+		if cfg.Database.User == "" || cfg.Database.Password == "" {
+			return nil, errors.New("DB_USER and DB_PASS are required for pgsql")
+		}
 		dsn := fmt.Sprintf("host=%s user=%s password=%s port=%s dbname=%s",
 			cfg.Database.Hostname,
 			cfg.Database.User,
@@ -26,7 +35,8 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 		)
 		dialector = postgres.Open(dsn)
 	} else {
-		dialector = sqlite.Open(cfg.Database.Name)
+		path = sqlitePath(cfg.Database)
+		dialector = sqlite.Open(sqliteDSN(path))
 	}
 
 	gormLogger := logger.New(
@@ -51,13 +61,64 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying db: %w", err)
 	}
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	if cfg.Database.Type != "pgsql" && isSQLiteMemory(path) {
+		// Every connection to an in-memory database opens a new, empty one, so keep
+		// a single connection and never let it expire.
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	}
 
-	// Auto-migrate schema
-	if err := db.AutoMigrate(&model.Provider{}); err != nil {
+	if err := Migrate(db); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	return db, nil
 }
+
+// DefaultSQLitePath is the SQLite database file opened when DB_PATH is not set.
+const DefaultSQLitePath = "./spm.db"
+
+// sqlitePath returns the SQLite database file to open. Before DB_PATH, the file was
+// named by DB_NAME, so when DB_PATH is not set and that file exists it is opened
+// instead of starting on a new, empty database.
+func sqlitePath(cfg *config.DBConfig) string {
+	if cfg.Path != "" {
+		return cfg.Path
+	}
+	if cfg.Name != "" {
+		if _, err := os.Stat(cfg.Name); err == nil {
+			slog.Warn("Opening the SQLite database named by DB_NAME; set DB_PATH to keep using it", "path", cfg.Name)
+			return cfg.Name
+		}
+	}
+	return DefaultSQLitePath
+}
+
+// sqliteBusyTimeout is how long a write waits for another connection's lock before
+// failing with "database is locked".
+const sqliteBusyTimeout = 5 * time.Second
+
+// sqliteDSN opens a database file in WAL mode, so reads do not block on a write,
+// with a busy timeout so concurrent writes wait for each other instead of failing.
+// In-memory databases are opened as they are.
+func sqliteDSN(path string) string {
+	if isSQLiteMemory(path) {
+		return path
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%s_journal_mode=WAL&_busy_timeout=%d", path, sep, sqliteBusyTimeout.Milliseconds())
+}
+
+// isSQLiteMemory reports whether the SQLite database name refers to an in-memory
+// database, which only lives as long as its connection.
+func isSQLiteMemory(name string) bool {
+	return name == ":memory:" || strings.HasPrefix(name, "file::memory:") || strings.Contains(name, "mode=memory")
+}
@@ -1,8 +1,14 @@
 package store_test
 
 import (
+	"context"
+	"path/filepath"
+	"time"
+
 	"github.com/dcm-project/service-provider-manager/internal/config"
 	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -12,7 +18,7 @@ var _ = Describe("InitDB", func() {
 		cfg := &config.Config{
 			Database: &config.DBConfig{
 				Type: "sqlite",
-				Name: ":memory:",
+				Path: ":memory:",
 			},
 		}
 
@@ -24,4 +30,106 @@ var _ = Describe("InitDB", func() {
 		sqlDB, _ := db.DB()
 		sqlDB.Close()
 	})
+
+	It("applies the connection pool settings", func() {
+		cfg := &config.Config{
+			Database: &config.DBConfig{
+				Type:            "sqlite",
+				Path:            filepath.Join(GinkgoT().TempDir(), "pool.db"),
+				MaxOpenConns:    7,
+				MaxIdleConns:    2,
+				ConnMaxLifetime: time.Minute,
+			},
+		}
+
+		db, err := store.InitDB(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB, _ := db.DB()
+		defer sqlDB.Close()
+
+		Expect(sqlDB.Stats().MaxOpenConnections).To(Equal(7))
+		Expect(sqlDB.Stats().Idle).To(BeNumerically("<=", 2))
+	})
+
+	It("keeps an in-memory SQLite database on a single connection", func() {
+		cfg := &config.Config{
+			Database: &config.DBConfig{
+				Type: "sqlite",
+				Path: ":memory:",
+			},
+		}
+
+		db, err := store.InitDB(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB, _ := db.DB()
+		defer sqlDB.Close()
+
+		Expect(sqlDB.Stats().MaxOpenConnections).To(Equal(1))
+		Expect(db.Migrator().HasTable(&model.Provider{})).To(BeTrue())
+	})
+
+	It("keeps a SQLite file database across restarts, in WAL mode", func() {
+		cfg := &config.Config{
+			Database: &config.DBConfig{
+				Type: "sqlite",
+				Path: filepath.Join(GinkgoT().TempDir(), "spm.db"),
+			},
+		}
+
+		db, err := store.InitDB(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		var journalMode string
+		Expect(db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error).To(Succeed())
+		Expect(journalMode).To(Equal("wal"))
+		created, err := store.NewProvider(db).Create(context.Background(), model.Provider{
+			ID: uuid.New(), Name: "persisted", ServiceType: "vm", SchemaVersion: "v1alpha1", Endpoint: "https://example.com",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB, _ := db.DB()
+		Expect(sqlDB.Close()).To(Succeed())
+
+		db, err = store.InitDB(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB, _ = db.DB()
+		defer sqlDB.Close()
+
+		provider, err := store.NewProvider(db).Get(context.Background(), created.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.Name).To(Equal("persisted"))
+	})
+
+	It("opens the SQLite database named by DB_NAME when DB_PATH is not set and it exists", func() {
+		legacy := filepath.Join(GinkgoT().TempDir(), "service-provider")
+		db, err := store.InitDB(&config.Config{Database: &config.DBConfig{Type: "sqlite", Path: legacy}})
+		Expect(err).NotTo(HaveOccurred())
+		created, err := store.NewProvider(db).Create(context.Background(), model.Provider{
+			ID: uuid.New(), Name: "existing", ServiceType: "vm", SchemaVersion: "v1alpha1", Endpoint: "https://example.com",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB, _ := db.DB()
+		Expect(sqlDB.Close()).To(Succeed())
+
+		db, err = store.InitDB(&config.Config{Database: &config.DBConfig{Type: "sqlite", Name: legacy}})
+		Expect(err).NotTo(HaveOccurred())
+		sqlDB, _ = db.DB()
+		defer sqlDB.Close()
+
+		_, err = store.NewProvider(db).Get(context.Background(), created.ID)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("requires credentials for PostgreSQL", func() {
+		cfg := &config.Config{
+			Database: &config.DBConfig{
+				Type:     "pgsql",
+				Hostname: "localhost",
+				Port:     "5432",
+				Name:     "service-provider",
+			},
+		}
+
+		_, err := store.InitDB(cfg)
+
+		Expect(err).To(MatchError(ContainSubstring("DB_USER and DB_PASS are required")))
+	})
 })
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// migration is one versioned change to the database schema. Migrations are applied
+// in order, each at most once, and must not be edited once released: a change to a
+// model needs a new migration.
+type migration struct {
+	id      string
+	migrate func(tx *gorm.DB) error
+}
+
+// migrations is the schema history, oldest first.
+var migrations = []migration{
+	{
+		// The schema as it was when versioned migrations were introduced. On databases
+		// created by AutoMigrate before then, it adds the missing tables, columns and
+		// indexes, but keeps existing indexes as they are: a changed index definition
+		// needs its own migration.
+		id: "0001_baseline",
+		migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&baselineProvider{}, &baselineServiceTypeInstance{}, &baselineHealthEvent{})
+		},
+	},
+	{
+		id: "0002_health_event_certificate",
+		migrate: func(tx *gorm.DB) error {
+			return addColumns(tx, &healthEventCertificate{}, "CertNotAfter", "Warning")
+		},
+	},
+	{
+		// Databases created before soft deletes have a unique index on every provider
+		// name under the same name, which AutoMigrate leaves in place, so deleted
+		// providers' names could not be reused.
+		id: "0003_providers_name_live_unique",
+		migrate: func(tx *gorm.DB) error {
+			return recreateIndex(tx, &baselineProvider{}, "idx_providers_name")
+		},
+	},
+	{
+		// Providers inserted without a health status have not been checked yet, so
+		// they start out unknown rather than ready.
+		id: "0004_providers_health_status_unknown",
+		migrate: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AlterColumn(&providerHealthStatusUnknown{}, "HealthStatus"); err != nil {
+				return err
+			}
+			// SQLite alters a column by recreating the table, which drops its indexes.
+			return createMissingIndexes(tx, &baselineProvider{}, "idx_providers_name", "idx_providers_delete_time")
+		},
+	},
+}
+
+// recreateIndex drops the named index, if it exists, and creates it as the model
+// defines it.
+func recreateIndex(tx *gorm.DB, model any, name string) error {
+	migrator := tx.Migrator()
+	if migrator.HasIndex(model, name) {
+		if err := migrator.DropIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return migrator.CreateIndex(model, name)
+}
+
+// createMissingIndexes creates the named indexes as the model defines them, skipping
+// those that exist.
+func createMissingIndexes(tx *gorm.DB, model any, names ...string) error {
+	migrator := tx.Migrator()
+	for _, name := range names {
+		if migrator.HasIndex(model, name) {
+			continue
+		}
+		if err := migrator.CreateIndex(model, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumns adds the fields of model as columns, skipping those that exist already
+// because the database was created by AutoMigrate from a newer model.
+func addColumns(tx *gorm.DB, model any, fields ...string) error {
+	migrator := tx.Migrator()
+	for _, field := range fields {
+		if migrator.HasColumn(model, field) {
+			continue
+		}
+		if err := migrator.AddColumn(model, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appliedMigration records a migration that has been applied.
+type appliedMigration struct {
+	ID        string    `gorm:"primaryKey"`
+	AppliedAt time.Time `gorm:"column:applied_at;not null"`
+}
+
+func (appliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate applies the migrations that have not been applied to the database yet,
+// each in its own transaction, and records them in the schema_migrations table.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&appliedMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	for _, m := range migrations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var applied int64
+			if err := tx.Model(&appliedMigration{}).Where("id = ?", m.id).Count(&applied).Error; err != nil {
+				return err
+			}
+			if applied > 0 {
+				return nil
+			}
+			if err := m.migrate(tx); err != nil {
+				return err
+			}
+			return tx.Create(&appliedMigration{ID: m.id, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", m.id, err)
+		}
+	}
+	return nil
+}
+
+// The baseline tables, frozen so that later changes to the models do not change
+// what the baseline migration creates.
+
+type baselineProvider struct {
+	ID                     uuid.UUID         `gorm:"primaryKey;type:uuid"`
+	Name                   string            `gorm:"uniqueIndex:idx_providers_name,where:delete_time IS NULL;not null"`
+	ServiceType            string            `gorm:"column:service_type;not null"`
+	SchemaVersion          string            `gorm:"column:schema_version;not null"`
+	Endpoint               string            `gorm:"column:endpoint;not null"`
+	CreateTime             time.Time         `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime             time.Time         `gorm:"column:update_time;autoUpdateTime"`
+	Version                int               `gorm:"column:version;not null;default:1"`
+	DeleteTime             gorm.DeletedAt    `gorm:"column:delete_time;index"`
+	HealthStatus           string            `gorm:"column:health_status;default:ready"`
+	ConsecutiveFailures    int               `gorm:"column:consecutive_failures;default:0"`
+	NextHealthCheck        *time.Time        `gorm:"column:next_health_check"`
+	MaintenanceStart       *time.Time        `gorm:"column:maintenance_start"`
+	MaintenanceEnd         *time.Time        `gorm:"column:maintenance_end"`
+	TimeoutSeconds         int               `gorm:"column:timeout_seconds;not null;default:0"`
+	HealthPath             *string           `gorm:"column:health_path;default:/health"`
+	HealthExpectedStatuses []int             `gorm:"column:health_expected_statuses;serializer:json"`
+	HealthCheckDisabled    bool              `gorm:"column:health_check_disabled;not null;default:false"`
+	CreatePath             string            `gorm:"column:create_path;not null;default:''"`
+	GetPath                string            `gorm:"column:get_path;not null;default:''"`
+	DeletePath             string            `gorm:"column:delete_path;not null;default:''"`
+	Labels                 datatypes.JSONMap `gorm:"column:labels"`
+	DebugLogging           bool              `gorm:"column:debug_logging;not null;default:false"`
+	AuthType               string            `gorm:"column:auth_type"`
+	AuthHeader             string            `gorm:"column:auth_header"`
+	AuthToken              string            `gorm:"column:auth_token"`
+}
+
+func (baselineProvider) TableName() string {
+	return "providers"
+}
+
+type baselineServiceTypeInstance struct {
+	ID                 uuid.UUID      `gorm:"primaryKey;type:uuid"`
+	ProviderName       string         `gorm:"column:provider_name;not null;uniqueIndex:idx_instances_provider_instance_name"`
+	Status             string         `gorm:"column:status;not null"`
+	InstanceName       string         `gorm:"column:instance_name;not null;uniqueIndex:idx_instances_provider_instance_name"`
+	ProviderInstanceID string         `gorm:"column:provider_instance_id"`
+	Spec               datatypes.JSON `gorm:"column:spec;not null"`
+	CreateTime         time.Time      `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime         time.Time      `gorm:"column:update_time;autoUpdateTime"`
+}
+
+func (baselineServiceTypeInstance) TableName() string {
+	return "service_type_instances"
+}
+
+type baselineHealthEvent struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProviderID uuid.UUID `gorm:"column:provider_id;type:uuid;not null;index:idx_health_events_provider_time"`
+	CheckTime  time.Time `gorm:"column:check_time;not null;index:idx_health_events_provider_time"`
+	Status     string    `gorm:"column:status;not null"`
+	HTTPStatus int       `gorm:"column:http_status;not null;default:0"`
+	LatencyMs  int64     `gorm:"column:latency_ms;not null;default:0"`
+	Error      string    `gorm:"column:error"`
+}
+
+func (baselineHealthEvent) TableName() string {
+	return "provider_health_events"
+}
+
+type healthEventCertificate struct {
+	CertNotAfter *time.Time `gorm:"column:cert_not_after"`
+	Warning      string     `gorm:"column:warning"`
+}
+
+func (healthEventCertificate) TableName() string {
+	return "provider_health_events"
+}
+
+type providerHealthStatusUnknown struct {
+	HealthStatus string `gorm:"column:health_status;default:unknown"`
+}
+
+func (providerHealthStatusUnknown) TableName() string {
+	return "providers"
+}
@@ -0,0 +1,133 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var _ = Describe("Migrate", func() {
+	var db *gorm.DB
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger:         logger.Default.LogMode(logger.Silent),
+			TranslateError: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	indexSQL := func(name string) string {
+		var sql string
+		Expect(db.Raw("SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", name).Scan(&sql).Error).To(Succeed())
+		return sql
+	}
+
+	appliedMigrations := func() []string {
+		var ids []string
+		Expect(db.Table("schema_migrations").Order("id").Pluck("id", &ids).Error).To(Succeed())
+		return ids
+	}
+
+	It("creates a schema that matches the models", func() {
+		Expect(store.Migrate(db)).To(Succeed())
+
+		report, err := store.NewSchema(db).Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.InSync()).To(BeTrue())
+		Expect(appliedMigrations()).To(Equal([]string{"0001_baseline", "0002_health_event_certificate", "0003_providers_name_live_unique", "0004_providers_health_status_unknown"}))
+	})
+
+	It("applies each migration once", func() {
+		Expect(store.Migrate(db)).To(Succeed())
+		Expect(store.Migrate(db)).To(Succeed())
+
+		Expect(appliedMigrations()).To(Equal([]string{"0001_baseline", "0002_health_event_certificate", "0003_providers_name_live_unique", "0004_providers_health_status_unknown"}))
+	})
+
+	It("adopts a database created before versioned migrations", func() {
+		Expect(db.AutoMigrate(&preMigrationsProvider{})).To(Succeed())
+		existing := newProvider("existing")
+		Expect(db.Create(&preMigrationsProvider{
+			ID: existing.ID, Name: existing.Name, ServiceType: "vm", SchemaVersion: "v1alpha1",
+			Endpoint: existing.Endpoint, HealthStatus: "not_ready", ConsecutiveFailures: 2,
+		}).Error).To(Succeed())
+
+		Expect(store.Migrate(db)).To(Succeed())
+
+		report, err := store.NewSchema(db).Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.InSync()).To(BeTrue())
+		Expect(appliedMigrations()).To(Equal([]string{"0001_baseline", "0002_health_event_certificate", "0003_providers_name_live_unique", "0004_providers_health_status_unknown"}))
+
+		provider, err := store.NewProvider(db).Get(context.Background(), existing.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.HealthStatus).To(Equal(model.HealthStatusNotReady))
+		Expect(provider.ConsecutiveFailures).To(Equal(2))
+		Expect(provider.Version).To(Equal(1))
+		Expect(provider.HealthCheckDisabled).To(BeFalse())
+
+		Expect(indexSQL("idx_providers_name")).To(ContainSubstring("WHERE delete_time IS NULL"))
+		Expect(indexSQL("idx_providers_delete_time")).NotTo(BeEmpty())
+		Expect(indexSQL("idx_instances_provider_instance_name")).To(ContainSubstring("UNIQUE"))
+
+		unchecked := newProvider("unchecked")
+		Expect(db.Exec("INSERT INTO providers (id, name, service_type, schema_version, endpoint) VALUES (?, ?, 'vm', 'v1alpha1', ?)",
+			unchecked.ID, unchecked.Name, unchecked.Endpoint).Error).To(Succeed())
+		provider, err = store.NewProvider(db).Get(context.Background(), unchecked.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.HealthStatus).To(Equal(model.HealthStatusUnknown))
+	})
+
+	It("lets a database created before soft deletes reuse deleted providers' names", func() {
+		Expect(db.AutoMigrate(&preMigrationsProvider{})).To(Succeed())
+		existing := newProvider("reused")
+		Expect(db.Create(&preMigrationsProvider{
+			ID: existing.ID, Name: existing.Name, ServiceType: "vm", SchemaVersion: "v1alpha1", Endpoint: existing.Endpoint,
+		}).Error).To(Succeed())
+
+		Expect(store.Migrate(db)).To(Succeed())
+
+		providerStore := store.NewProvider(db)
+		ctx := context.Background()
+		_, err := providerStore.Create(ctx, newProvider("reused"))
+		Expect(err).To(MatchError(store.ErrProviderNameTaken))
+		Expect(providerStore.Delete(ctx, existing.ID)).To(Succeed())
+		_, err = providerStore.Create(ctx, newProvider("reused"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+// preMigrationsProvider is the providers table as AutoMigrate created it before
+// versioned migrations, soft deletes and most provider settings, with a unique index
+// on every name.
+type preMigrationsProvider struct {
+	ID                  uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	Name                string     `gorm:"uniqueIndex;not null"`
+	ServiceType         string     `gorm:"column:service_type;not null"`
+	SchemaVersion       string     `gorm:"column:schema_version;not null"`
+	Endpoint            string     `gorm:"column:endpoint;not null"`
+	CreateTime          time.Time  `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime          time.Time  `gorm:"column:update_time;autoUpdateTime"`
+	HealthStatus        string     `gorm:"column:health_status;default:ready"`
+	ConsecutiveFailures int        `gorm:"column:consecutive_failures;default:0"`
+	NextHealthCheck     *time.Time `gorm:"column:next_health_check"`
+}
+
+func (preMigrationsProvider) TableName() string {
+	return "providers"
+}
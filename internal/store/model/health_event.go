@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HealthEvent is the outcome of one health check of a provider.
+type HealthEvent struct {
+	ID         uuid.UUID    `gorm:"primaryKey;type:uuid"`
+	ProviderID uuid.UUID    `gorm:"column:provider_id;type:uuid;not null;index:idx_health_events_provider_time"`
+	CheckTime  time.Time    `gorm:"column:check_time;not null;index:idx_health_events_provider_time"`
+	Status     HealthStatus `gorm:"column:status;not null"`
+	// HTTPStatus is the status code of the health endpoint's response, 0 when there
+	// was no response
+	HTTPStatus int    `gorm:"column:http_status;not null;default:0"`
+	LatencyMs  int64  `gorm:"column:latency_ms;not null;default:0"`
+	Error      string `gorm:"column:error"`
+	// CertNotAfter is when the certificate of an https endpoint expires
+	CertNotAfter *time.Time `gorm:"column:cert_not_after"`
+	// Warning flags a problem that does not fail the check, such as a certificate
+	// about to expire
+	Warning string `gorm:"column:warning"`
+}
+
+// TableName returns the table health events are stored in.
+func (HealthEvent) TableName() string {
+	return "provider_health_events"
+}
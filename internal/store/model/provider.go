@@ -1,9 +1,14 @@
 package model
 
 import (
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 // HealthStatus represents the health status of a provider
@@ -14,26 +19,151 @@ const (
 	HealthStatusReady HealthStatus = "ready"
 	// HealthStatusNotReady indicates the provider is not healthy or unreachable
 	HealthStatusNotReady HealthStatus = "not_ready"
+	// HealthStatusMaintenance indicates the provider failed a health check during its maintenance window
+	HealthStatusMaintenance HealthStatus = "maintenance"
+	// HealthStatusUnknown indicates the provider has not been health checked since it was registered
+	HealthStatusUnknown HealthStatus = "unknown"
 )
 
+// AuthType is how credentials are sent to a provider's endpoints
+type AuthType string
+
+const (
+	// AuthTypeBearer sends the token as "Authorization: Bearer <token>"
+	AuthTypeBearer AuthType = "bearer"
+	// AuthTypeHeader sends the token as the value of AuthHeader
+	AuthTypeHeader AuthType = "header"
+)
+
+// StringPtr returns the status as a string pointer, or nil when the status is empty.
 func (h HealthStatus) StringPtr() *string {
+	if h == "" {
+		return nil
+	}
 	s := string(h)
 	return &s
 }
 
 type Provider struct {
 	ID            uuid.UUID `gorm:"primaryKey;type:uuid"`
-	Name          string    `gorm:"uniqueIndex;not null"`
+	Name          string    `gorm:"uniqueIndex:idx_providers_name,where:delete_time IS NULL;not null"`
 	ServiceType   string    `gorm:"column:service_type;not null"`
 	SchemaVersion string    `gorm:"column:schema_version;not null"`
 	Endpoint      string    `gorm:"column:endpoint;not null"`
 	CreateTime    time.Time `gorm:"column:create_time;autoCreateTime"`
 	UpdateTime    time.Time `gorm:"column:update_time;autoUpdateTime"`
 
+	// Version is incremented by every update of the provider's settings, so that
+	// concurrent updates can be detected. Health check results do not change it.
+	Version int `gorm:"column:version;not null;default:1"`
+
+	// DeleteTime is set when the provider is soft deleted. gorm excludes soft deleted
+	// providers from queries unless Unscoped is used, and their names can be reused.
+	DeleteTime gorm.DeletedAt `gorm:"column:delete_time;index"`
+
 	// Health check fields
-	HealthStatus        HealthStatus `gorm:"column:health_status;default:ready"`
+	HealthStatus        HealthStatus `gorm:"column:health_status;default:unknown"`
 	ConsecutiveFailures int          `gorm:"column:consecutive_failures;default:0"`
 	NextHealthCheck     *time.Time   `gorm:"column:next_health_check"`
+
+	// Planned maintenance window during which failed health checks are not counted
+	MaintenanceStart *time.Time `gorm:"column:maintenance_start"`
+	MaintenanceEnd   *time.Time `gorm:"column:maintenance_end"`
+
+	// TimeoutSeconds overrides the global health check timeout when non-zero
+	TimeoutSeconds int `gorm:"column:timeout_seconds;not null;default:0"`
+
+	// Health check target. HealthPath is appended to the endpoint, nil meaning
+	// DefaultHealthPath and empty the endpoint itself. With HealthExpectedStatuses set,
+	// only those status codes count as healthy instead of any 2xx.
+	HealthPath             *string `gorm:"column:health_path;default:/health"`
+	HealthExpectedStatuses []int   `gorm:"column:health_expected_statuses;serializer:json"`
+
+	// HealthCheckDisabled skips health checks; the provider is always ready
+	HealthCheckDisabled bool `gorm:"column:health_check_disabled;not null;default:false"`
+
+	// Paths of the provider's instance API, appended to the endpoint. Empty means
+	// the endpoint itself for creates and DefaultInstancePath for gets and deletes;
+	// "{id}" is replaced by the provider-side instance ID.
+	CreatePath string `gorm:"column:create_path;not null;default:''"`
+	GetPath    string `gorm:"column:get_path;not null;default:''"`
+	DeletePath string `gorm:"column:delete_path;not null;default:''"`
+
+	// Labels are key/value pairs for grouping providers, matched by label selectors
+	Labels datatypes.JSONMap `gorm:"column:labels"`
+
+	// DebugLogging enables detailed logging of outbound calls to this provider
+	DebugLogging bool `gorm:"column:debug_logging;not null;default:false"`
+
+	// Credentials sent with calls to the provider; AuthType is empty when none are needed
+	AuthType   AuthType `gorm:"column:auth_type"`
+	AuthHeader string   `gorm:"column:auth_header"`
+	AuthToken  string   `gorm:"column:auth_token"`
+}
+
+// InMaintenance reports whether now falls within the provider's maintenance window.
+func (p Provider) InMaintenance(now time.Time) bool {
+	return p.MaintenanceStart != nil && p.MaintenanceEnd != nil &&
+		!now.Before(*p.MaintenanceStart) && now.Before(*p.MaintenanceEnd)
+}
+
+// DefaultHealthPath is checked for providers that do not set a health path.
+const DefaultHealthPath = "/health"
+
+// HealthURL returns the URL the provider's health is checked at.
+func (p Provider) HealthURL() string {
+	path := DefaultHealthPath
+	if p.HealthPath != nil {
+		path = *p.HealthPath
+	}
+	return joinPath(p.Endpoint, path)
+}
+
+// DefaultInstancePath addresses an instance for providers that do not set a get or
+// delete path.
+const DefaultInstancePath = "/{id}"
+
+// InstanceIDPlaceholder is replaced by the provider-side instance ID in instance paths.
+const InstanceIDPlaceholder = "{id}"
+
+// CreateURL returns the URL instances are created at.
+func (p Provider) CreateURL() string {
+	return joinPath(p.Endpoint, p.CreatePath)
+}
+
+// GetURL returns the URL the instance with the provider-side ID is read from.
+func (p Provider) GetURL(id string) string {
+	return p.instanceURL(p.GetPath, id)
+}
+
+// DeleteURL returns the URL the instance with the provider-side ID is deleted at.
+func (p Provider) DeleteURL(id string) string {
+	return p.instanceURL(p.DeletePath, id)
+}
+
+func (p Provider) instanceURL(path, id string) string {
+	if path == "" {
+		path = DefaultInstancePath
+	}
+	return joinPath(p.Endpoint, strings.ReplaceAll(path, InstanceIDPlaceholder, url.PathEscape(id)))
+}
+
+// joinPath appends path to endpoint with a single slash between them. An empty
+// path is the endpoint itself.
+func joinPath(endpoint, path string) string {
+	if path == "" {
+		return endpoint
+	}
+	return strings.TrimRight(endpoint, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// HealthyStatus reports whether a health check response with the status code means
+// the provider is healthy.
+func (p Provider) HealthyStatus(code int) bool {
+	if len(p.HealthExpectedStatuses) > 0 {
+		return slices.Contains(p.HealthExpectedStatuses, code)
+	}
+	return code >= 200 && code < 300
 }
 
 type ProviderList []Provider
@@ -8,13 +8,25 @@ import (
 )
 
 type ServiceTypeInstance struct {
-	ID           uuid.UUID      `gorm:"primaryKey;type:uuid"`
-	ProviderName string         `gorm:"column:provider_name;not null"`
-	Status       string         `gorm:"column:status;not null"`
-	InstanceName string         `gorm:"column:instance_name;not null"`
-	Spec         datatypes.JSON `gorm:"column:spec;not null"`
-	CreateTime   time.Time      `gorm:"column:create_time;autoCreateTime"`
-	UpdateTime   time.Time      `gorm:"column:update_time;autoUpdateTime"`
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ProviderName string    `gorm:"column:provider_name;not null;uniqueIndex:idx_instances_provider_instance_name"`
+	Status       string    `gorm:"column:status;not null"`
+	// InstanceName is unique among the instances of a provider
+	InstanceName string `gorm:"column:instance_name;not null;uniqueIndex:idx_instances_provider_instance_name"`
+	// ProviderInstanceID is the ID the provider assigned to the instance, when it
+	// differs from ours. Calls to the provider address the instance by it.
+	ProviderInstanceID string         `gorm:"column:provider_instance_id"`
+	Spec               datatypes.JSON `gorm:"column:spec;not null"`
+	CreateTime         time.Time      `gorm:"column:create_time;autoCreateTime"`
+	UpdateTime         time.Time      `gorm:"column:update_time;autoUpdateTime"`
 }
 
 type ServiceTypeInstanceList []ServiceTypeInstance
+
+// ProviderID returns the ID the provider knows the instance by.
+func (i ServiceTypeInstance) ProviderID() string {
+	if i.ProviderInstanceID != "" {
+		return i.ProviderInstanceID
+	}
+	return i.ID.String()
+}
@@ -2,7 +2,13 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
@@ -14,27 +20,87 @@ import (
 var (
 	ErrProviderNotFound  = errors.New("provider not found")
 	ErrProviderNameTaken = errors.New("provider name already taken")
+	ErrProviderModified  = errors.New("provider was modified concurrently")
+	ErrInvalidOrder      = errors.New("invalid provider order")
 )
 
+// ProviderWithInstanceCount is a provider together with its number of service type
+// instances.
+type ProviderWithInstanceCount struct {
+	model.Provider
+	InstanceCount int64
+}
+
 // ProviderFilter contains optional fields for filtering provider queries.
 // nil fields are ignored (not filtered).
 type ProviderFilter struct {
-	Name        *string
-	ServiceType *string
+	Name *string
+	// NameContains matches providers whose name contains it, literally: % and _ are
+	// not wildcards.
+	NameContains *string
+	ServiceType  *string
+	HealthStatus *model.HealthStatus
+	// LabelSelector matches providers having all of these labels, and possibly others
+	LabelSelector map[string]string
 }
 
 // Pagination contains options for paginated queries.
+// With After set, the page starts after that row instead of at Offset. A nil Order
+// lists providers by creation time.
 type Pagination struct {
 	Limit  int
 	Offset int
+	After  *ProviderCursor
+	Order  *ProviderOrder
+}
+
+// Fields providers can be ordered by.
+const (
+	OrderByName       = "name"
+	OrderByCreateTime = "create_time"
+	OrderByUpdateTime = "update_time"
+)
+
+// providerOrderColumns maps the fields providers can be ordered by to their
+// columns. Only these columns are ever put in the ORDER BY clause.
+var providerOrderColumns = map[string]string{
+	OrderByName:       "name",
+	OrderByCreateTime: "create_time",
+	OrderByUpdateTime: "update_time",
+}
+
+// ProviderOrder is a list order of providers. Ties are broken by id in the same
+// direction, so the order is total.
+type ProviderOrder struct {
+	Field string
+	Desc  bool
+}
+
+// DefaultProviderOrder lists providers by creation time, oldest first.
+var DefaultProviderOrder = ProviderOrder{Field: OrderByCreateTime}
+
+// ValidProviderOrderField reports whether providers can be ordered by field.
+func ValidProviderOrderField(field string) bool {
+	_, ok := providerOrderColumns[field]
+	return ok
+}
+
+// ProviderCursor identifies a row in the list order by its value of the order
+// field and its id.
+type ProviderCursor struct {
+	AfterValue any
+	AfterID    uuid.UUID
 }
 
 type Provider interface {
 	List(ctx context.Context, filter *ProviderFilter, pagination *Pagination) (model.ProviderList, error)
+	ListWithInstanceCounts(ctx context.Context, filter *ProviderFilter, pagination *Pagination) ([]ProviderWithInstanceCount, error)
 	Count(ctx context.Context, filter *ProviderFilter) (int64, error)
 	Create(ctx context.Context, provider model.Provider) (*model.Provider, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) (*model.Provider, error)
 	Update(ctx context.Context, provider model.Provider) (*model.Provider, error)
+	Patch(ctx context.Context, id uuid.UUID, version *int, fields map[string]any) (*model.Provider, error)
 	Get(ctx context.Context, id uuid.UUID) (*model.Provider, error)
 	GetByName(ctx context.Context, name string) (*model.Provider, error)
 	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
@@ -42,6 +108,8 @@ type Provider interface {
 	// Health check methods
 	ListProvidersForHealthCheck(ctx context.Context, now time.Time) (model.ProviderList, error)
 	UpdateHealthStatus(ctx context.Context, id uuid.UUID, status model.HealthStatus, consecutiveFailures int, nextCheck time.Time) error
+	RecordHealthEvent(ctx context.Context, event model.HealthEvent, keep int) error
+	ListHealthEvents(ctx context.Context, providerID uuid.UUID, limit int) ([]model.HealthEvent, error)
 }
 
 type ProviderStore struct {
@@ -56,42 +124,111 @@ func NewProvider(db *gorm.DB) Provider {
 
 func (s *ProviderStore) List(ctx context.Context, filter *ProviderFilter, pagination *Pagination) (model.ProviderList, error) {
 	var providers model.ProviderList
-	query := s.db.WithContext(ctx)
+	query := listQuery(s.db.WithContext(ctx), filter, pagination)
 
-	if filter != nil {
-		if filter.Name != nil {
-			query = query.Where(&model.Provider{Name: *filter.Name})
-		}
-		if filter.ServiceType != nil {
-			query = query.Where(&model.Provider{ServiceType: *filter.ServiceType})
-		}
+	if err := query.Find(&providers).Error; err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// ListWithInstanceCounts is List with the number of service type instances of each
+// provider, counted in the same query.
+func (s *ProviderStore) ListWithInstanceCounts(ctx context.Context, filter *ProviderFilter, pagination *Pagination) ([]ProviderWithInstanceCount, error) {
+	var providers []ProviderWithInstanceCount
+	query := listQuery(s.db.WithContext(ctx).Model(&model.Provider{}), filter, pagination).
+		Select("providers.*, (?) AS instance_count",
+			s.db.Model(&model.ServiceTypeInstance{}).
+				Select("COUNT(*)").
+				Where("service_type_instances.provider_name = providers.name"))
+
+	if err := query.Scan(&providers).Error; err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// listQuery applies the filter, ordering and pagination shared by the list queries.
+func listQuery(query *gorm.DB, filter *ProviderFilter, pagination *Pagination) *gorm.DB {
+	query = filterQuery(query, filter)
+
+	order := DefaultProviderOrder
+	if pagination != nil && pagination.Order != nil {
+		order = *pagination.Order
+	}
+	column, ok := providerOrderColumns[order.Field]
+	if !ok {
+		_ = query.AddError(fmt.Errorf("%w: unknown field %q", ErrInvalidOrder, order.Field))
+		return query
+	}
+	direction, after := "ASC", ">"
+	if order.Desc {
+		direction, after = "DESC", "<"
 	}
 
 	// Apply consistent ordering for pagination
-	query = query.Order("create_time ASC, id ASC")
+	query = query.Order(fmt.Sprintf("%s %s, id %s", column, direction, direction))
 
 	if pagination != nil {
-		query = query.Limit(pagination.Limit).Offset(pagination.Offset)
+		if pagination.After != nil {
+			// Keyset pagination: rows inserted or deleted before the cursor do not
+			// shift the page.
+			query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, after), pagination.After.AfterValue, pagination.After.AfterID)
+		} else {
+			query = query.Offset(pagination.Offset)
+		}
+		query = query.Limit(pagination.Limit)
 	}
+	return query
+}
 
-	if err := query.Find(&providers).Error; err != nil {
-		return nil, err
+// filterQuery applies the set filter fields, combined with AND.
+func filterQuery(query *gorm.DB, filter *ProviderFilter) *gorm.DB {
+	if filter == nil {
+		return query
 	}
-	return providers, nil
+	if filter.Name != nil {
+		query = query.Where(&model.Provider{Name: *filter.Name})
+	}
+	if filter.NameContains != nil {
+		query = query.Where(`name LIKE ? ESCAPE '\'`, "%"+escapeLike(*filter.NameContains)+"%")
+	}
+	if filter.ServiceType != nil {
+		query = query.Where(&model.Provider{ServiceType: *filter.ServiceType})
+	}
+	if filter.HealthStatus != nil {
+		query = query.Where(&model.Provider{HealthStatus: *filter.HealthStatus})
+	}
+	if len(filter.LabelSelector) > 0 {
+		query = labelQuery(query, filter.LabelSelector)
+	}
+	return query
+}
+
+// labelQuery matches providers whose labels contain the selector: with JSON
+// containment on postgres, and one JSON_EXTRACT comparison per label elsewhere.
+// Label keys are validated by the service and cannot contain quotes.
+func labelQuery(query *gorm.DB, selector map[string]string) *gorm.DB {
+	if query.Dialector.Name() == "postgres" {
+		labels, _ := json.Marshal(selector)
+		return query.Where("labels @> ?", string(labels))
+	}
+	for _, key := range slices.Sorted(maps.Keys(selector)) {
+		query = query.Where("JSON_EXTRACT(labels, ?) = ?", `$."`+key+`"`, selector[key])
+	}
+	return query
+}
+
+// likeEscaper escapes the LIKE wildcards, and the escape character itself.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
 }
 
 func (s *ProviderStore) Count(ctx context.Context, filter *ProviderFilter) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&model.Provider{})
-
-	if filter != nil {
-		if filter.Name != nil {
-			query = query.Where(&model.Provider{Name: *filter.Name})
-		}
-		if filter.ServiceType != nil {
-			query = query.Where(&model.Provider{ServiceType: *filter.ServiceType})
-		}
-	}
+	query := filterQuery(s.db.WithContext(ctx).Model(&model.Provider{}), filter)
 
 	if err := query.Count(&count).Error; err != nil {
 		return 0, err
@@ -99,13 +236,19 @@ func (s *ProviderStore) Count(ctx context.Context, filter *ProviderFilter) (int6
 	return count, nil
 }
 
+// Create inserts a new provider. Returns ErrProviderNameTaken when the insert violates
+// a unique constraint, which relies on the gorm TranslateError option set by InitDB.
 func (s *ProviderStore) Create(ctx context.Context, provider model.Provider) (*model.Provider, error) {
 	if err := s.db.WithContext(ctx).Clauses(clause.Returning{}).Create(&provider).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrProviderNameTaken
+		}
 		return nil, err
 	}
 	return &provider, nil
 }
 
+// Delete soft deletes a provider. It is excluded from every other query until restored.
 func (s *ProviderStore) Delete(ctx context.Context, id uuid.UUID) error {
 	result := s.db.WithContext(ctx).Delete(&model.Provider{}, id)
 	if result.Error != nil {
@@ -117,17 +260,112 @@ func (s *ProviderStore) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (s *ProviderStore) Update(ctx context.Context, provider model.Provider) (*model.Provider, error) {
-	result := s.db.WithContext(ctx).Model(&provider).Clauses(clause.Returning{}).Updates(&provider)
+// Restore undoes the soft delete of a provider. Returns ErrProviderNotFound when no
+// deleted provider has the ID, or ErrProviderNameTaken when its name has been reused.
+func (s *ProviderStore) Restore(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
+	result := s.db.WithContext(ctx).Unscoped().Model(&model.Provider{}).
+		Where("id = ? AND delete_time IS NOT NULL", id).
+		Update("delete_time", nil)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, ErrProviderNameTaken
+		}
 		return nil, result.Error
 	}
 	if result.RowsAffected == 0 {
 		return nil, ErrProviderNotFound
 	}
+	return s.Get(ctx, id)
+}
+
+// Update replaces the provider's settings. It only applies while the stored version
+// is still provider.Version, and increments it. Returns ErrProviderModified when
+// another update came first, ErrProviderNotFound for a missing provider, or
+// ErrProviderNameTaken when a new name is already in use.
+func (s *ProviderStore) Update(ctx context.Context, provider model.Provider) (*model.Provider, error) {
+	version := provider.Version
+	provider.Version++
+	result := s.db.WithContext(ctx).Model(&provider).Clauses(clause.Returning{}).
+		Where("version = ?", version).
+		Select("*").Omit("id", "create_time", "delete_time", "health_status", "consecutive_failures", "next_health_check").
+		Updates(&provider)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, ErrProviderNameTaken
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, s.notUpdated(ctx, provider.ID)
+	}
 	return &provider, nil
 }
 
+// Patch sets only the given columns of a provider, keyed by column name, and
+// increments its version. When version is set, the patch only applies while the
+// stored version is still version. Returns ErrProviderNotFound for a missing
+// provider, ErrProviderModified when the version no longer matches, or
+// ErrProviderNameTaken when a new name is already in use.
+func (s *ProviderStore) Patch(ctx context.Context, id uuid.UUID, version *int, fields map[string]any) (*model.Provider, error) {
+	provider := model.Provider{ID: id}
+	fields, err := s.serialize(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+	fields["version"] = gorm.Expr("version + 1")
+	query := s.db.WithContext(ctx).Model(&provider).Clauses(clause.Returning{})
+	if version != nil {
+		query = query.Where("version = ?", *version)
+	}
+	result := query.Updates(fields)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return nil, ErrProviderNameTaken
+		}
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, s.notUpdated(ctx, id)
+	}
+	return &provider, nil
+}
+
+// serialize returns a copy of the fields with the values of serialized columns,
+// such as health_expected_statuses, encoded: updates from a map store the values
+// as they are.
+func (s *ProviderStore) serialize(ctx context.Context, fields map[string]any) (map[string]any, error) {
+	stmt := &gorm.Statement{DB: s.db}
+	if err := stmt.Parse(&model.Provider{}); err != nil {
+		return nil, err
+	}
+	serialized := maps.Clone(fields)
+	for column, value := range fields {
+		field := stmt.Schema.LookUpField(column)
+		if field == nil || field.Serializer == nil {
+			continue
+		}
+		encoded, err := field.Serializer.Value(ctx, field, reflect.Value{}, value)
+		if err != nil {
+			return nil, fmt.Errorf("serialize %s: %w", column, err)
+		}
+		serialized[column] = encoded
+	}
+	return serialized, nil
+}
+
+// notUpdated explains why a versioned update of the provider changed no rows:
+// ErrProviderModified when it exists, ErrProviderNotFound otherwise.
+func (s *ProviderStore) notUpdated(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.Provider{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrProviderModified
+	}
+	return ErrProviderNotFound
+}
+
 func (s *ProviderStore) Get(ctx context.Context, id uuid.UUID) (*model.Provider, error) {
 	var provider model.Provider
 	if err := s.db.WithContext(ctx).First(&provider, id).Error; err != nil {
@@ -150,9 +388,10 @@ func (s *ProviderStore) GetByName(ctx context.Context, name string) (*model.Prov
 	return &provider, nil
 }
 
+// ExistsByID reports whether the ID is in use, including by a soft deleted provider.
 func (s *ProviderStore) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
 	var provider model.Provider
-	err := s.db.WithContext(ctx).Select("id").Where(&model.Provider{ID: id}).Take(&provider).Error
+	err := s.db.WithContext(ctx).Unscoped().Select("id").Where(&model.Provider{ID: id}).Take(&provider).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return false, nil
@@ -188,3 +427,34 @@ func (s *ProviderStore) UpdateHealthStatus(ctx context.Context, id uuid.UUID, st
 	}
 	return nil
 }
+
+// RecordHealthEvent stores the outcome of a health check and deletes the oldest
+// events of the provider beyond the newest keep.
+func (s *ProviderStore) RecordHealthEvent(ctx context.Context, event model.HealthEvent, keep int) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		newest := tx.Model(&model.HealthEvent{}).Select("id").
+			Where("provider_id = ?", event.ProviderID).
+			Order("check_time DESC, id DESC").Limit(keep)
+		return tx.Where("provider_id = ? AND id NOT IN (?)", event.ProviderID, newest).
+			Delete(&model.HealthEvent{}).Error
+	})
+}
+
+// ListHealthEvents returns up to limit of the provider's most recent health events,
+// newest first.
+func (s *ProviderStore) ListHealthEvents(ctx context.Context, providerID uuid.UUID, limit int) ([]model.HealthEvent, error) {
+	var events []model.HealthEvent
+	if err := s.db.WithContext(ctx).
+		Where("provider_id = ?", providerID).
+		Order("check_time DESC, id DESC").Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -2,13 +2,16 @@ package store_test
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/store"
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	rmstore "github.com/dcm-project/service-provider-manager/internal/store/resource_manager"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gorm.io/datatypes"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -24,10 +27,11 @@ var _ = Describe("Provider Store", func() {
 	BeforeEach(func() {
 		var err error
 		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Silent),
+			Logger:         logger.Default.LogMode(logger.Silent),
+			TranslateError: true,
 		})
 		Expect(err).NotTo(HaveOccurred())
-		Expect(db.AutoMigrate(&model.Provider{})).To(Succeed())
+		Expect(db.AutoMigrate(&model.Provider{}, &model.ServiceTypeInstance{}, &model.HealthEvent{})).To(Succeed())
 
 		providerStore = store.NewProvider(db)
 		ctx = context.Background()
@@ -56,7 +60,7 @@ var _ = Describe("Provider Store", func() {
 
 			p2 := newProvider("duplicate-name")
 			_, err = providerStore.Create(ctx, p2)
-			Expect(err).To(HaveOccurred())
+			Expect(err).To(MatchError(store.ErrProviderNameTaken))
 		})
 	})
 
@@ -154,6 +158,100 @@ var _ = Describe("Provider Store", func() {
 			Expect(providers[0].Name).To(Equal("vm-one"))
 		})
 
+		It("filters by a name prefix or substring", func() {
+			providerStore.Create(ctx, newProvider("east-vm"))
+			providerStore.Create(ctx, newProvider("west-vm"))
+			providerStore.Create(ctx, newProvider("east-container"))
+
+			prefix := "east-"
+			providers, err := providerStore.List(ctx, &store.ProviderFilter{NameContains: &prefix}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(2))
+
+			substring := "t-v"
+			providers, err = providerStore.List(ctx, &store.ProviderFilter{NameContains: &substring}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(2))
+			Expect([]string{providers[0].Name, providers[1].Name}).To(ConsistOf("east-vm", "west-vm"))
+		})
+
+		It("matches LIKE wildcards in the name literally", func() {
+			providerStore.Create(ctx, newProvider("under_score"))
+			providerStore.Create(ctx, newProvider("underXscore"))
+			providerStore.Create(ctx, newProvider("100%-vm"))
+
+			underscore := "r_s"
+			providers, err := providerStore.List(ctx, &store.ProviderFilter{NameContains: &underscore}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(1))
+			Expect(providers[0].Name).To(Equal("under_score"))
+
+			percent := "%"
+			count, err := providerStore.Count(ctx, &store.ProviderFilter{NameContains: &percent})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+
+			backslash := `\`
+			count, err = providerStore.Count(ctx, &store.ProviderFilter{NameContains: &backslash})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("filters by label selector", func() {
+			labeled := func(name string, labels datatypes.JSONMap) {
+				p := newProvider(name)
+				p.Labels = labels
+				_, err := providerStore.Create(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			labeled("east-infra", datatypes.JSONMap{"region": "us-east", "team": "infra"})
+			labeled("east-infra-gpu", datatypes.JSONMap{"region": "us-east", "team": "infra", "gpu": "true"})
+			labeled("east-apps", datatypes.JSONMap{"region": "us-east", "team": "apps"})
+			labeled("west-infra", datatypes.JSONMap{"region": "us-west", "team": "infra"})
+			labeled("unlabeled", nil)
+
+			providers, err := providerStore.List(ctx, &store.ProviderFilter{LabelSelector: map[string]string{"region": "us-east"}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(3))
+
+			providers, err = providerStore.List(ctx, &store.ProviderFilter{LabelSelector: map[string]string{"region": "us-east", "team": "infra"}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]string{providers[0].Name, providers[1].Name}).To(ConsistOf("east-infra", "east-infra-gpu"))
+			Expect(providers).To(HaveLen(2))
+
+			count, err := providerStore.Count(ctx, &store.ProviderFilter{LabelSelector: map[string]string{"team": "infra", "gpu": "false"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("filters by health status", func() {
+			up, _ := providerStore.Create(ctx, newProvider("health-up"))
+			down, _ := providerStore.Create(ctx, newProvider("health-down"))
+			Expect(providerStore.UpdateHealthStatus(ctx, up.ID, model.HealthStatusReady, 0, time.Now())).To(Succeed())
+			Expect(providerStore.UpdateHealthStatus(ctx, down.ID, model.HealthStatusNotReady, 3, time.Now())).To(Succeed())
+
+			notReady := model.HealthStatusNotReady
+			providers, err := providerStore.List(ctx, &store.ProviderFilter{HealthStatus: &notReady}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(1))
+			Expect(providers[0].ID).To(Equal(down.ID))
+
+			ready := model.HealthStatusReady
+			count, err := providerStore.Count(ctx, &store.ProviderFilter{HealthStatus: &ready})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+
+		It("starts providers created without a health status out unknown", func() {
+			created, err := providerStore.Create(ctx, newProvider("unchecked"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.HealthStatus).To(Equal(model.HealthStatusUnknown))
+
+			stored, err := providerStore.Get(ctx, created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.HealthStatus).To(Equal(model.HealthStatusUnknown))
+		})
+
 		It("respects pagination limit", func() {
 			providerStore.Create(ctx, newProvider("page-p1"))
 			providerStore.Create(ctx, newProvider("page-p2"))
@@ -175,6 +273,46 @@ var _ = Describe("Provider Store", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(providers).To(HaveLen(1))
 		})
+
+		It("starts after the cursor row", func() {
+			providerStore.Create(ctx, newProvider("cursor-p1"))
+			providerStore.Create(ctx, newProvider("cursor-p2"))
+			providerStore.Create(ctx, newProvider("cursor-p3"))
+			first, err := providerStore.List(ctx, nil, &store.Pagination{Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			cursor := &store.ProviderCursor{AfterValue: first[0].CreateTime, AfterID: first[0].ID}
+			providers, err := providerStore.List(ctx, nil, &store.Pagination{Limit: 10, After: cursor})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(2))
+			Expect(providers).NotTo(ContainElement(HaveField("ID", first[0].ID)))
+		})
+
+		It("orders by name descending", func() {
+			providerStore.Create(ctx, newProvider("order-b"))
+			providerStore.Create(ctx, newProvider("order-c"))
+			providerStore.Create(ctx, newProvider("order-a"))
+
+			order := &store.ProviderOrder{Field: store.OrderByName, Desc: true}
+			first, err := providerStore.List(ctx, nil, &store.Pagination{Limit: 1, Order: order})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).To(HaveLen(1))
+			Expect(first[0].Name).To(Equal("order-c"))
+
+			cursor := &store.ProviderCursor{AfterValue: first[0].Name, AfterID: first[0].ID}
+			rest, err := providerStore.List(ctx, nil, &store.Pagination{Limit: 10, After: cursor, Order: order})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rest).To(HaveLen(2))
+			Expect(rest[0].Name).To(Equal("order-b"))
+			Expect(rest[1].Name).To(Equal("order-a"))
+		})
+
+		It("rejects an order field outside the allowlist", func() {
+			_, err := providerStore.List(ctx, nil, &store.Pagination{Limit: 10, Order: &store.ProviderOrder{Field: "endpoint"}})
+
+			Expect(err).To(MatchError(store.ErrInvalidOrder))
+		})
 	})
 
 	Describe("Count", func() {
@@ -220,12 +358,89 @@ var _ = Describe("Provider Store", func() {
 
 			Expect(err).To(Equal(store.ErrProviderNotFound))
 		})
+
+		It("soft deletes the provider", func() {
+			p := newProvider("soft-deleted")
+			providerStore.Create(ctx, p)
+
+			Expect(providerStore.Delete(ctx, p.ID)).To(Succeed())
+
+			_, err := providerStore.Get(ctx, p.ID)
+			Expect(err).To(Equal(store.ErrProviderNotFound))
+			providers, err := providerStore.List(ctx, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(BeEmpty())
+			withCounts, err := providerStore.ListWithInstanceCounts(ctx, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(withCounts).To(BeEmpty())
+			Expect(providerStore.Count(ctx, nil)).To(BeZero())
+			Expect(providerStore.Delete(ctx, p.ID)).To(Equal(store.ErrProviderNotFound))
+
+			var count int64
+			Expect(db.Unscoped().Model(&model.Provider{}).Where("id = ?", p.ID).Count(&count).Error).To(Succeed())
+			Expect(count).To(Equal(int64(1)))
+		})
+
+		It("frees the name but not the ID", func() {
+			p := newProvider("reused")
+			providerStore.Create(ctx, p)
+			Expect(providerStore.Delete(ctx, p.ID)).To(Succeed())
+
+			_, err := providerStore.Create(ctx, newProvider("reused"))
+			Expect(err).NotTo(HaveOccurred())
+			exists, err := providerStore.ExistsByID(ctx, p.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+	})
+
+	Describe("Restore", func() {
+		It("brings back a deleted provider", func() {
+			p := newProvider("to-restore")
+			providerStore.Create(ctx, p)
+			Expect(providerStore.Delete(ctx, p.ID)).To(Succeed())
+
+			restored, err := providerStore.Restore(ctx, p.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored.Name).To(Equal("to-restore"))
+			_, err = providerStore.Get(ctx, p.ID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns ErrProviderNotFound for a provider that is not deleted", func() {
+			p := newProvider("not-deleted")
+			providerStore.Create(ctx, p)
+
+			_, err := providerStore.Restore(ctx, p.ID)
+
+			Expect(err).To(Equal(store.ErrProviderNotFound))
+		})
+
+		It("returns ErrProviderNotFound for missing ID", func() {
+			_, err := providerStore.Restore(ctx, uuid.New())
+
+			Expect(err).To(Equal(store.ErrProviderNotFound))
+		})
+
+		It("returns ErrProviderNameTaken when the name was reused", func() {
+			p := newProvider("taken")
+			providerStore.Create(ctx, p)
+			Expect(providerStore.Delete(ctx, p.ID)).To(Succeed())
+			_, err := providerStore.Create(ctx, newProvider("taken"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = providerStore.Restore(ctx, p.ID)
+
+			Expect(err).To(Equal(store.ErrProviderNameTaken))
+		})
 	})
 
 	Describe("Update", func() {
 		It("modifies existing provider", func() {
-			p := newProvider("to-update")
-			providerStore.Create(ctx, p)
+			created, err := providerStore.Create(ctx, newProvider("to-update"))
+			Expect(err).NotTo(HaveOccurred())
+			p := *created
 
 			p.Endpoint = "https://new-endpoint.com"
 			updated, err := providerStore.Update(ctx, p)
@@ -234,6 +449,68 @@ var _ = Describe("Provider Store", func() {
 			Expect(updated.Endpoint).To(Equal("https://new-endpoint.com"))
 		})
 
+		It("clears fields set to their zero value and keeps the health fields", func() {
+			p := newProvider("to-clear")
+			start, end := time.Now(), time.Now().Add(time.Hour)
+			p.MaintenanceStart, p.MaintenanceEnd = &start, &end
+			created, err := providerStore.Create(ctx, p)
+			Expect(err).NotTo(HaveOccurred())
+			p = *created
+			Expect(providerStore.UpdateHealthStatus(ctx, p.ID, model.HealthStatusNotReady, 4, end)).To(Succeed())
+
+			p.MaintenanceStart, p.MaintenanceEnd = nil, nil
+			_, err = providerStore.Update(ctx, p)
+			Expect(err).NotTo(HaveOccurred())
+
+			stored, err := providerStore.Get(ctx, p.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.MaintenanceStart).To(BeNil())
+			Expect(stored.MaintenanceEnd).To(BeNil())
+			Expect(stored.HealthStatus).To(Equal(model.HealthStatusNotReady))
+			Expect(stored.ConsecutiveFailures).To(Equal(4))
+		})
+
+		It("increments the version", func() {
+			created, err := providerStore.Create(ctx, newProvider("versioned"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.Version).To(Equal(1))
+
+			updated, err := providerStore.Update(ctx, *created)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Version).To(Equal(2))
+		})
+
+		It("returns ErrProviderModified for a stale version", func() {
+			created, err := providerStore.Create(ctx, newProvider("stale"))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = providerStore.Update(ctx, *created)
+			Expect(err).NotTo(HaveOccurred())
+
+			created.Endpoint = "https://stale.example.com"
+			_, err = providerStore.Update(ctx, *created)
+
+			Expect(err).To(Equal(store.ErrProviderModified))
+			stored, err := providerStore.Get(ctx, created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Endpoint).To(Equal("https://example.com/api"))
+		})
+
+		It("returns ErrProviderNameTaken when renaming onto another provider's name", func() {
+			_, err := providerStore.Create(ctx, newProvider("taken"))
+			Expect(err).NotTo(HaveOccurred())
+			created, err := providerStore.Create(ctx, newProvider("to-rename"))
+			Expect(err).NotTo(HaveOccurred())
+
+			created.Name = "taken"
+			_, err = providerStore.Update(ctx, *created)
+
+			Expect(err).To(Equal(store.ErrProviderNameTaken))
+			stored, err := providerStore.Get(ctx, created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Name).To(Equal("to-rename"))
+		})
+
 		It("returns ErrProviderNotFound for non-existing provider", func() {
 			p := newProvider("non-existing")
 			_, err := providerStore.Update(ctx, p)
@@ -242,6 +519,49 @@ var _ = Describe("Provider Store", func() {
 		})
 	})
 
+	Describe("ListWithInstanceCounts", func() {
+		BeforeEach(func() {
+			vm, container := newProvider("vm-provider"), newProvider("container-provider")
+			container.ServiceType = "container"
+			providerStore.Create(ctx, vm)
+			providerStore.Create(ctx, container)
+
+			instances := rmstore.NewServiceTypeInstance(db)
+			for i, providerName := range []string{"vm-provider", "vm-provider", "container-provider"} {
+				_, err := instances.Create(ctx, model.ServiceTypeInstance{
+					ID:           uuid.New(),
+					ProviderName: providerName,
+					Status:       "RUNNING",
+					InstanceName: fmt.Sprintf("instance-%d", i),
+					Spec:         []byte("{}"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns each provider with its instance count", func() {
+			providers, err := providerStore.ListWithInstanceCounts(ctx, nil, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(2))
+			counts := map[string]int64{}
+			for _, p := range providers {
+				counts[p.Name] = p.InstanceCount
+			}
+			Expect(counts).To(Equal(map[string]int64{"vm-provider": 2, "container-provider": 1}))
+		})
+
+		It("applies the filter", func() {
+			serviceType := "container"
+			providers, err := providerStore.ListWithInstanceCounts(ctx, &store.ProviderFilter{ServiceType: &serviceType}, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(providers).To(HaveLen(1))
+			Expect(providers[0].Name).To(Equal("container-provider"))
+			Expect(providers[0].InstanceCount).To(BeEquivalentTo(1))
+		})
+	})
+
 	Describe("ListProvidersForHealthCheck", func() {
 		It("returns providers with null next_health_check", func() {
 			p := newProvider("null-next-check")
@@ -332,6 +652,49 @@ var _ = Describe("Provider Store", func() {
 		})
 	})
 
+	Describe("health events", func() {
+		record := func(providerID uuid.UUID, at time.Time, keep int) {
+			Expect(providerStore.RecordHealthEvent(ctx, model.HealthEvent{
+				ProviderID: providerID, CheckTime: at, Status: model.HealthStatusReady, HTTPStatus: 200,
+			}, keep)).To(Succeed())
+		}
+
+		It("lists a provider's events newest first", func() {
+			providerID, otherID := uuid.New(), uuid.New()
+			start := time.Now().Truncate(time.Second)
+			for i := range 3 {
+				record(providerID, start.Add(time.Duration(i)*time.Second), 10)
+			}
+			record(otherID, start, 10)
+
+			events, err := providerStore.ListHealthEvents(ctx, providerID, 2)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].CheckTime).To(BeTemporally("==", start.Add(2*time.Second)))
+			Expect(events[1].CheckTime).To(BeTemporally("==", start.Add(time.Second)))
+			Expect(events[0].HTTPStatus).To(Equal(200))
+		})
+
+		It("keeps only the newest events of each provider", func() {
+			providerID, otherID := uuid.New(), uuid.New()
+			start := time.Now().Truncate(time.Second)
+			record(otherID, start, 3)
+			for i := range 5 {
+				record(providerID, start.Add(time.Duration(i)*time.Second), 3)
+			}
+
+			events, err := providerStore.ListHealthEvents(ctx, providerID, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+			Expect(events[2].CheckTime).To(BeTemporally("==", start.Add(2*time.Second)))
+
+			others, err := providerStore.ListHealthEvents(ctx, otherID, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(others).To(HaveLen(1))
+		})
+	})
+
 	Describe("UpdateHealthStatus", func() {
 		It("updates health status to not_ready", func() {
 			p := newProvider("health-update")
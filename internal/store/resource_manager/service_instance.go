@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
 	"github.com/google/uuid"
@@ -12,12 +13,18 @@ import (
 
 var (
 	ErrInstanceNotFound = errors.New("service type instance not found")
+	ErrInstanceConflict = errors.New("service type instance already exists")
 )
 
 // ServiceTypeInstanceFilter contains optional fields for filtering instance queries.
 // nil fields are ignored (not filtered).
 type ServiceTypeInstanceFilter struct {
 	ProviderName *string
+	// Statuses matches instances in any of the statuses; empty is not filtered.
+	Statuses []string
+	// CreatedAfter and CreatedBefore bound the creation time, inclusive and exclusive.
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
 }
 
 // Pagination contains options for paginated queries.
@@ -32,6 +39,13 @@ type ServiceTypeInstance interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	Get(ctx context.Context, id uuid.UUID) (*model.ServiceTypeInstance, error)
 	ExistsByID(ctx context.Context, id uuid.UUID) (bool, error)
+	Count(ctx context.Context, filter *ServiceTypeInstanceFilter) (int64, error)
+	CountGroupedByStatus(ctx context.Context, filter *ServiceTypeInstanceFilter) (map[string]int64, error)
+	CountByProvider(ctx context.Context, providerName string) (int64, error)
+
+	// Reconciliation methods
+	ListNotInStatus(ctx context.Context, statuses []string) (model.ServiceTypeInstanceList, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
 }
 
 type ServiceTypeInstanceStore struct {
@@ -49,13 +63,7 @@ func (s *ServiceTypeInstanceStore) List(
 	pagination *Pagination) (model.ServiceTypeInstanceList, error) {
 
 	var instances model.ServiceTypeInstanceList
-	query := s.db.WithContext(ctx)
-
-	if filter != nil {
-		if filter.ProviderName != nil {
-			query = query.Where(&model.ServiceTypeInstance{ProviderName: *filter.ProviderName})
-		}
-	}
+	query := filterQuery(s.db.WithContext(ctx), filter)
 
 	// Apply consistent ordering for pagination
 	query = query.Order("create_time ASC, id ASC")
@@ -70,8 +78,44 @@ func (s *ServiceTypeInstanceStore) List(
 	return instances, nil
 }
 
+// Count returns the number of instances matching the filter.
+func (s *ServiceTypeInstanceStore) Count(ctx context.Context, filter *ServiceTypeInstanceFilter) (int64, error) {
+	var count int64
+	query := filterQuery(s.db.WithContext(ctx).Model(&model.ServiceTypeInstance{}), filter)
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// filterQuery applies the set filter fields, combined with AND.
+func filterQuery(query *gorm.DB, filter *ServiceTypeInstanceFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+	if filter.ProviderName != nil {
+		query = query.Where(&model.ServiceTypeInstance{ProviderName: *filter.ProviderName})
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("create_time >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("create_time < ?", *filter.CreatedBefore)
+	}
+	return query
+}
+
+// Create inserts a new instance. Returns ErrInstanceConflict when an instance with the
+// same ID, or with the same name for the same provider, already exists, which relies
+// on the gorm TranslateError option set by InitDB.
 func (s *ServiceTypeInstanceStore) Create(ctx context.Context, instance model.ServiceTypeInstance) (*model.ServiceTypeInstance, error) {
 	if err := s.db.WithContext(ctx).Clauses(clause.Returning{}).Create(&instance).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrInstanceConflict
+		}
 		return nil, err
 	}
 	return &instance, nil
@@ -99,14 +143,72 @@ func (s *ServiceTypeInstanceStore) Get(ctx context.Context, id uuid.UUID) (*mode
 	return &instance, nil
 }
 
+// ExistsByID reports whether an instance with the ID exists. A missing instance is
+// not an error, so callers can check whether an ID is free to use.
 func (s *ServiceTypeInstanceStore) ExistsByID(ctx context.Context, id uuid.UUID) (bool, error) {
 	var instance model.ServiceTypeInstance
 	err := s.db.WithContext(ctx).Select("id").Where(&model.ServiceTypeInstance{ID: id}).Take(&instance).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return false, ErrInstanceNotFound
+			return false, nil
 		}
 		return false, err
 	}
 	return true, nil
 }
+
+// CountByProvider returns the number of instances of the named provider.
+func (s *ServiceTypeInstanceStore) CountByProvider(ctx context.Context, providerName string) (int64, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.ServiceTypeInstance{}).
+		Where(&model.ServiceTypeInstance{ProviderName: providerName}).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountGroupedByStatus returns the number of instances per status. Statuses with no
+// instances are absent from the result.
+func (s *ServiceTypeInstanceStore) CountGroupedByStatus(ctx context.Context, filter *ServiceTypeInstanceFilter) (map[string]int64, error) {
+	query := filterQuery(s.db.WithContext(ctx).Model(&model.ServiceTypeInstance{}), filter)
+
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := query.Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// ListNotInStatus returns the instances whose status is none of statuses, oldest first.
+func (s *ServiceTypeInstanceStore) ListNotInStatus(ctx context.Context, statuses []string) (model.ServiceTypeInstanceList, error) {
+	var instances model.ServiceTypeInstanceList
+	query := s.db.WithContext(ctx).Order("create_time ASC, id ASC")
+	if len(statuses) > 0 {
+		query = query.Where("status NOT IN ?", statuses)
+	}
+	if err := query.Find(&instances).Error; err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// UpdateStatus sets the status of an instance and bumps its update time.
+func (s *ServiceTypeInstanceStore) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	result := s.db.WithContext(ctx).Model(&model.ServiceTypeInstance{ID: id}).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInstanceNotFound
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package store_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/dcm-project/service-provider-manager/internal/store/model"
 	rmstore "github.com/dcm-project/service-provider-manager/internal/store/resource_manager"
@@ -45,7 +48,8 @@ var _ = Describe("ServiceTypeInstance Store", func() {
 	BeforeEach(func() {
 		var err error
 		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Silent),
+			Logger:         logger.Default.LogMode(logger.Silent),
+			TranslateError: true,
 		})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(db.AutoMigrate(&model.ServiceTypeInstance{})).To(Succeed())
@@ -71,6 +75,45 @@ var _ = Describe("ServiceTypeInstance Store", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(created.ID).To(Equal(instance.ID))
 		})
+
+		It("returns ErrInstanceConflict to the loser of concurrent creates with the same ID", func() {
+			sqlDB, err := db.DB()
+			Expect(err).NotTo(HaveOccurred())
+			sqlDB.SetMaxOpenConns(1)
+
+			id := uuid.New()
+			errs := make([]error, 2)
+			var wg sync.WaitGroup
+			for i := range errs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					instance := newServiceTypeInstance(kubevirtProvider, fmt.Sprintf("instance-%d", i), map[string]any{"cpu": 2})
+					instance.ID = id
+					_, errs[i] = s.Create(ctx, instance)
+				}()
+			}
+			wg.Wait()
+
+			Expect(errs).To(ContainElement(BeNil()))
+			Expect(errs).To(ContainElement(MatchError(rmstore.ErrInstanceConflict)))
+		})
+
+		It("returns ErrInstanceConflict for a duplicate name within the same provider", func() {
+			addInstanceToStore(newServiceTypeInstance(kubevirtProvider, "web", map[string]any{}))
+
+			_, err := s.Create(ctx, newServiceTypeInstance(kubevirtProvider, "web", map[string]any{}))
+
+			Expect(err).To(MatchError(rmstore.ErrInstanceConflict))
+		})
+
+		It("allows the same name for different providers", func() {
+			addInstanceToStore(newServiceTypeInstance(kubevirtProvider, "web", map[string]any{}))
+
+			_, err := s.Create(ctx, newServiceTypeInstance("container-sp", "web", map[string]any{}))
+
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Describe("Get", func() {
@@ -105,12 +148,66 @@ var _ = Describe("ServiceTypeInstance Store", func() {
 			Expect(instances).To(HaveLen(3))
 		})
 
+		It("filters by any of the statuses", func() {
+			for i, status := range []string{"RUNNING", "FAILED", "DELETED"} {
+				instance := newServiceTypeInstance("container-sp", fmt.Sprintf("mixed-%d", i), map[string]any{})
+				instance.Status = status
+				addInstanceToStore(instance)
+			}
+
+			instances, err := s.List(ctx, &rmstore.ServiceTypeInstanceFilter{Statuses: []string{"FAILED", "PROVISIONING"}}, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(4))
+			for _, instance := range instances {
+				Expect(instance.Status).To(BeElementOf("FAILED", "PROVISIONING"))
+			}
+		})
+
+		It("combines the status and provider filters", func() {
+			failed := newServiceTypeInstance("container-sp", "failed", map[string]any{})
+			failed.Status = "FAILED"
+			addInstanceToStore(failed)
+			provider := "container-sp"
+
+			instances, err := s.List(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &provider, Statuses: []string{"PROVISIONING", "FAILED"}}, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(1))
+			Expect(instances[0].InstanceName).To(Equal("failed"))
+		})
+
 		It("filters by provider name", func() {
 			instances, err := s.List(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &kubevirtProvider}, nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(instances).To(HaveLen(3))
 		})
 
+		It("filters by creation time range", func() {
+			base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i, name := range []string{"jan", "feb", "mar"} {
+				instance := newServiceTypeInstance("dated-sp", name, map[string]any{})
+				instance.CreateTime = base.AddDate(0, i, 0)
+				addInstanceToStore(instance)
+			}
+			provider := "dated-sp"
+			after, before := base.AddDate(0, 1, 0), base.AddDate(0, 2, 0)
+
+			instances, err := s.List(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &provider, CreatedAfter: &after}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(2))
+			Expect(instances[0].InstanceName).To(Equal("feb"))
+
+			instances, err = s.List(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &provider, CreatedAfter: &after, CreatedBefore: &before}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(1))
+			Expect(instances[0].InstanceName).To(Equal("feb"))
+
+			count, err := s.Count(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &provider, CreatedBefore: &before})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeNumerically("==", 2))
+		})
+
 		It("applies pagination limit/offset", func() {
 			firstTwo, err := s.List(ctx, nil, &rmstore.Pagination{Limit: 2, Offset: 0})
 			Expect(err).NotTo(HaveOccurred())
@@ -149,10 +246,96 @@ var _ = Describe("ServiceTypeInstance Store", func() {
 			Expect(exists).To(BeTrue())
 		})
 
-		It("returns ErrInstanceNotFound when instance is missing", func() {
+		It("reports a new ID as not existing without an error", func() {
 			exists, err := s.ExistsByID(ctx, uuid.New())
-			Expect(err).To(MatchError(rmstore.ErrInstanceNotFound))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(exists).To(BeFalse())
 		})
 	})
+	Describe("CountByProvider", func() {
+		It("counts the instances of the provider", func() {
+			addInstanceToStore(newServiceTypeInstance(kubevirtProvider, "kv-0", map[string]any{}))
+			addInstanceToStore(newServiceTypeInstance(kubevirtProvider, "kv-1", map[string]any{}))
+			addInstanceToStore(newServiceTypeInstance("container-sp", "ct-0", map[string]any{}))
+
+			Expect(s.CountByProvider(ctx, kubevirtProvider)).To(Equal(int64(2)))
+			Expect(s.CountByProvider(ctx, "unknown-sp")).To(BeZero())
+		})
+	})
+	Describe("Count", func() {
+		It("counts the instances matching the filter", func() {
+			for i, status := range []string{"RUNNING", "FAILED", "RUNNING"} {
+				instance := newServiceTypeInstance(kubevirtProvider, fmt.Sprintf("kv-%d", i), map[string]any{})
+				instance.Status = status
+				addInstanceToStore(instance)
+			}
+			addInstanceToStore(newServiceTypeInstance("container-sp", "ct-0", map[string]any{}))
+
+			Expect(s.Count(ctx, nil)).To(Equal(int64(4)))
+			provider := kubevirtProvider
+			Expect(s.Count(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &provider, Statuses: []string{"RUNNING"}})).To(Equal(int64(2)))
+		})
+	})
+	Describe("CountGroupedByStatus", func() {
+		otherProvider := "container-sp"
+
+		BeforeEach(func() {
+			for i, status := range []string{"RUNNING", "RUNNING", "PROVISIONING", "FAILED"} {
+				instance := newServiceTypeInstance(kubevirtProvider, fmt.Sprintf("kv-%d", i), map[string]any{})
+				instance.Status = status
+				addInstanceToStore(instance)
+			}
+			for i, status := range []string{"RUNNING", "FAILED"} {
+				instance := newServiceTypeInstance(otherProvider, fmt.Sprintf("ct-%d", i), map[string]any{})
+				instance.Status = status
+				addInstanceToStore(instance)
+			}
+		})
+
+		It("counts instances per status across all providers", func() {
+			counts, err := s.CountGroupedByStatus(ctx, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int64{"RUNNING": 3, "PROVISIONING": 1, "FAILED": 2}))
+		})
+
+		It("applies the provider filter", func() {
+			counts, err := s.CountGroupedByStatus(ctx, &rmstore.ServiceTypeInstanceFilter{ProviderName: &otherProvider})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int64{"RUNNING": 1, "FAILED": 1}))
+		})
+	})
+
+	Describe("ListNotInStatus", func() {
+		It("skips instances in the given statuses", func() {
+			for i, status := range []string{"RUNNING", "PROVISIONING", "FAILED", "DELETING"} {
+				instance := newServiceTypeInstance(kubevirtProvider, fmt.Sprintf("kv-%d", i), map[string]any{})
+				instance.Status = status
+				addInstanceToStore(instance)
+			}
+
+			instances, err := s.ListNotInStatus(ctx, []string{"RUNNING", "FAILED"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(2))
+			Expect(instances).To(ContainElements(HaveField("Status", "PROVISIONING"), HaveField("Status", "DELETING")))
+		})
+	})
+
+	Describe("UpdateStatus", func() {
+		It("updates the status", func() {
+			created := addInstanceToStore(newServiceTypeInstance(kubevirtProvider, "kv", map[string]any{}))
+
+			Expect(s.UpdateStatus(ctx, created.ID, "RUNNING")).To(Succeed())
+
+			instance, err := s.Get(ctx, created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Status).To(Equal("RUNNING"))
+		})
+
+		It("returns ErrInstanceNotFound for a missing instance", func() {
+			Expect(s.UpdateStatus(ctx, uuid.New(), "RUNNING")).To(MatchError(rmstore.ErrInstanceNotFound))
+		})
+	})
 })
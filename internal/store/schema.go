@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"gorm.io/gorm"
+)
+
+// models lists every model whose table is managed by the service migrations.
+var models = []any{
+	&model.Provider{},
+	&model.ServiceTypeInstance{},
+	&model.HealthEvent{},
+}
+
+// SchemaReport describes the differences between the database schema and the models.
+// Columns are reported as "table.column".
+type SchemaReport struct {
+	MissingTables  []string
+	MissingColumns []string
+	ExtraColumns   []string
+}
+
+// InSync reports whether the database schema matches the models.
+func (r *SchemaReport) InSync() bool {
+	return len(r.MissingTables) == 0 && len(r.MissingColumns) == 0 && len(r.ExtraColumns) == 0
+}
+
+// Migrated reports whether every table and column of the models exists. Extra
+// columns left by older versions do not matter.
+func (r *SchemaReport) Migrated() bool {
+	return len(r.MissingTables) == 0 && len(r.MissingColumns) == 0
+}
+
+type Schema interface {
+	Check(ctx context.Context) (*SchemaReport, error)
+	Migrate(ctx context.Context, allowDestructive bool) (*SchemaReport, error)
+}
+
+type SchemaStore struct {
+	db *gorm.DB
+}
+
+var _ Schema = (*SchemaStore)(nil)
+
+func NewSchema(db *gorm.DB) Schema {
+	return &SchemaStore{db: db}
+}
+
+// Check compares the database schema with the models using the gorm migrator.
+func (s *SchemaStore) Check(ctx context.Context) (*SchemaReport, error) {
+	db := s.db.WithContext(ctx)
+	migrator := db.Migrator()
+	report := &SchemaReport{}
+
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return nil, fmt.Errorf("failed to parse model: %w", err)
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(m) {
+			report.MissingTables = append(report.MissingTables, table)
+			continue
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(m, field.DBName) {
+				report.MissingColumns = append(report.MissingColumns, table+"."+field.DBName)
+			}
+		}
+
+		columnTypes, err := migrator.ColumnTypes(m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		for _, column := range columnTypes {
+			if _, ok := stmt.Schema.FieldsByDBName[column.Name()]; !ok {
+				report.ExtraColumns = append(report.ExtraColumns, table+"."+column.Name())
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// Migrate applies the versioned migrations the database has not seen yet, as at
+// startup. Columns that are no longer mapped by any model are then dropped, but only
+// when allowDestructive is set. Returns the schema report after migrating.
+func (s *SchemaStore) Migrate(ctx context.Context, allowDestructive bool) (*SchemaReport, error) {
+	db := s.db.WithContext(ctx)
+	if err := Migrate(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	if allowDestructive {
+		migrator := db.Migrator()
+		for _, m := range models {
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(m); err != nil {
+				return nil, fmt.Errorf("failed to parse model: %w", err)
+			}
+			columnTypes, err := migrator.ColumnTypes(m)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read columns of %s: %w", stmt.Schema.Table, err)
+			}
+			for _, column := range columnTypes {
+				if _, ok := stmt.Schema.FieldsByDBName[column.Name()]; ok {
+					continue
+				}
+				if err := migrator.DropColumn(m, column.Name()); err != nil {
+					return nil, fmt.Errorf("failed to drop column %s.%s: %w", stmt.Schema.Table, column.Name(), err)
+				}
+			}
+		}
+	}
+
+	return s.Check(ctx)
+}
@@ -0,0 +1,118 @@
+package store_test
+
+import (
+	"context"
+
+	"github.com/dcm-project/service-provider-manager/internal/store"
+	"github.com/dcm-project/service-provider-manager/internal/store/model"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// legacyProvider mimics an outdated providers table without the health columns.
+type legacyProvider struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Name          string    `gorm:"uniqueIndex;not null"`
+	ServiceType   string    `gorm:"column:service_type;not null"`
+	SchemaVersion string    `gorm:"column:schema_version;not null"`
+	Endpoint      string    `gorm:"column:endpoint;not null"`
+	LegacyField   string    `gorm:"column:legacy_field"`
+}
+
+func (legacyProvider) TableName() string {
+	return "providers"
+}
+
+var _ = Describe("Schema Store", func() {
+	var (
+		db          *gorm.DB
+		schemaStore store.Schema
+		ctx         context.Context
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		schemaStore = store.NewSchema(db)
+		ctx = context.Background()
+	})
+
+	AfterEach(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	Describe("Check", func() {
+		It("reports missing tables on an empty database", func() {
+			report, err := schemaStore.Check(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.InSync()).To(BeFalse())
+			Expect(report.MissingTables).To(ContainElement("providers"))
+		})
+
+		It("reports missing and extra columns on an outdated schema", func() {
+			Expect(db.Migrator().CreateTable(&legacyProvider{}, &model.ServiceTypeInstance{}, &model.HealthEvent{})).To(Succeed())
+
+			report, err := schemaStore.Check(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.InSync()).To(BeFalse())
+			Expect(report.MissingTables).To(BeEmpty())
+			Expect(report.MissingColumns).To(ContainElements(
+				"providers.health_status",
+				"providers.consecutive_failures",
+				"providers.next_health_check",
+			))
+			Expect(report.ExtraColumns).To(ConsistOf("providers.legacy_field"))
+		})
+	})
+
+	Describe("Migrate", func() {
+		It("adds missing columns but keeps extra columns by default", func() {
+			Expect(db.Migrator().CreateTable(&legacyProvider{})).To(Succeed())
+
+			report, err := schemaStore.Migrate(ctx, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.MissingColumns).To(BeEmpty())
+			Expect(report.ExtraColumns).To(ConsistOf("providers.legacy_field"))
+			Expect(db.Migrator().HasColumn("providers", "legacy_field")).To(BeTrue())
+		})
+
+		It("drops extra columns when destructive changes are allowed", func() {
+			Expect(db.Migrator().CreateTable(&legacyProvider{})).To(Succeed())
+
+			report, err := schemaStore.Migrate(ctx, true)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.InSync()).To(BeTrue())
+			Expect(db.Migrator().HasColumn("providers", "legacy_field")).To(BeFalse())
+		})
+
+		It("creates missing tables", func() {
+			report, err := schemaStore.Migrate(ctx, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.InSync()).To(BeTrue())
+		})
+
+		It("applies and records the versioned migrations, as at startup", func() {
+			_, err := schemaStore.Migrate(ctx, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			var applied []string
+			Expect(db.Table("schema_migrations").Order("id").Pluck("id", &applied).Error).To(Succeed())
+			Expect(applied).To(Equal([]string{"0001_baseline", "0002_health_event_certificate", "0003_providers_name_live_unique", "0004_providers_health_status_unknown"}))
+			Expect(store.Migrate(db)).To(Succeed())
+		})
+	})
+})
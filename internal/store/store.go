@@ -1,20 +1,25 @@
 package store
 
 import (
+	"context"
+
 	store "github.com/dcm-project/service-provider-manager/internal/store/resource_manager"
 	"gorm.io/gorm"
 )
 
 type Store interface {
 	Close() error
+	Ping(ctx context.Context) error
 	Provider() Provider
 	ServiceTypeInstance() store.ServiceTypeInstance
+	Schema() Schema
 }
 
 type DataStore struct {
 	db       *gorm.DB
 	provider Provider
 	instance store.ServiceTypeInstance
+	schema   Schema
 }
 
 func NewStore(db *gorm.DB) Store {
@@ -22,6 +27,7 @@ func NewStore(db *gorm.DB) Store {
 		db:       db,
 		provider: NewProvider(db),
 		instance: store.NewServiceTypeInstance(db),
+		schema:   NewSchema(db),
 	}
 }
 
@@ -33,6 +39,15 @@ func (s *DataStore) Close() error {
 	return sqlDB.Close()
 }
 
+// Ping verifies the database connection is alive.
+func (s *DataStore) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
 func (s *DataStore) Provider() Provider {
 	return s.provider
 }
@@ -40,3 +55,7 @@ func (s *DataStore) Provider() Provider {
 func (s *DataStore) ServiceTypeInstance() store.ServiceTypeInstance {
 	return s.instance
 }
+
+func (s *DataStore) Schema() Schema {
+	return s.schema
+}
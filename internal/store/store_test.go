@@ -1,6 +1,8 @@
 package store_test
 
 import (
+	"context"
+
 	"github.com/dcm-project/service-provider-manager/internal/store"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -47,4 +49,19 @@ var _ = Describe("Store", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("Ping", func() {
+		It("succeeds on an open connection", func() {
+			s := store.NewStore(db)
+
+			Expect(s.Ping(context.Background())).To(Succeed())
+		})
+
+		It("fails once the connection is closed", func() {
+			s := store.NewStore(db)
+			Expect(s.Close()).To(Succeed())
+
+			Expect(s.Ping(context.Background())).To(HaveOccurred())
+		})
+	})
 })